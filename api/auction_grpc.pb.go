@@ -20,6 +20,13 @@ const _ = grpc.SupportPackageIsVersion7
 type AuctionClient interface {
 	Bid(ctx context.Context, in *BidRequest, opts ...grpc.CallOption) (*BidReply, error)
 	GetResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultReply, error)
+	ListAuctions(ctx context.Context, in *ListAuctionsRequest, opts ...grpc.CallOption) (*ListAuctionsReply, error)
+	ListBids(ctx context.Context, in *ListBidsRequest, opts ...grpc.CallOption) (*ListBidsReply, error)
+	WatchAuction(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Auction_WatchAuctionClient, error)
+	BidSession(ctx context.Context, opts ...grpc.CallOption) (Auction_BidSessionClient, error)
+	// Takes back a bid within the retract window of the auction
+	RetractBid(ctx context.Context, in *RetractRequest, opts ...grpc.CallOption) (*RetractReply, error)
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountReply, error)
 }
 
 type auctionClient struct {
@@ -48,12 +55,118 @@ func (c *auctionClient) GetResult(ctx context.Context, in *ResultRequest, opts .
 	return out, nil
 }
 
+func (c *auctionClient) ListAuctions(ctx context.Context, in *ListAuctionsRequest, opts ...grpc.CallOption) (*ListAuctionsReply, error) {
+	out := new(ListAuctionsReply)
+	err := c.cc.Invoke(ctx, "/Auction/ListAuctions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionClient) ListBids(ctx context.Context, in *ListBidsRequest, opts ...grpc.CallOption) (*ListBidsReply, error) {
+	out := new(ListBidsReply)
+	err := c.cc.Invoke(ctx, "/Auction/ListBids", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionClient) WatchAuction(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Auction_WatchAuctionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Auction_ServiceDesc.Streams[0], "/Auction/WatchAuction", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &auctionWatchAuctionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Auction_WatchAuctionClient interface {
+	Recv() (*AuctionEvent, error)
+	grpc.ClientStream
+}
+
+type auctionWatchAuctionClient struct {
+	grpc.ClientStream
+}
+
+func (x *auctionWatchAuctionClient) Recv() (*AuctionEvent, error) {
+	m := new(AuctionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *auctionClient) BidSession(ctx context.Context, opts ...grpc.CallOption) (Auction_BidSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Auction_ServiceDesc.Streams[1], "/Auction/BidSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &auctionBidSessionClient{stream}
+	return x, nil
+}
+
+type Auction_BidSessionClient interface {
+	Send(*SessionRequest) error
+	Recv() (*SessionMessage, error)
+	grpc.ClientStream
+}
+
+type auctionBidSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *auctionBidSessionClient) Send(m *SessionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *auctionBidSessionClient) Recv() (*SessionMessage, error) {
+	m := new(SessionMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *auctionClient) RetractBid(ctx context.Context, in *RetractRequest, opts ...grpc.CallOption) (*RetractReply, error) {
+	out := new(RetractReply)
+	err := c.cc.Invoke(ctx, "/Auction/RetractBid", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountReply, error) {
+	out := new(AccountReply)
+	err := c.cc.Invoke(ctx, "/Auction/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuctionServer is the server API for Auction service.
 // All implementations must embed UnimplementedAuctionServer
 // for forward compatibility
 type AuctionServer interface {
 	Bid(context.Context, *BidRequest) (*BidReply, error)
 	GetResult(context.Context, *ResultRequest) (*ResultReply, error)
+	ListAuctions(context.Context, *ListAuctionsRequest) (*ListAuctionsReply, error)
+	ListBids(context.Context, *ListBidsRequest) (*ListBidsReply, error)
+	WatchAuction(*WatchRequest, Auction_WatchAuctionServer) error
+	BidSession(Auction_BidSessionServer) error
+	// Takes back a bid within the retract window of the auction
+	RetractBid(context.Context, *RetractRequest) (*RetractReply, error)
+	GetAccount(context.Context, *GetAccountRequest) (*AccountReply, error)
 	mustEmbedUnimplementedAuctionServer()
 }
 
@@ -67,6 +180,24 @@ func (UnimplementedAuctionServer) Bid(context.Context, *BidRequest) (*BidReply,
 func (UnimplementedAuctionServer) GetResult(context.Context, *ResultRequest) (*ResultReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
 }
+func (UnimplementedAuctionServer) ListAuctions(context.Context, *ListAuctionsRequest) (*ListAuctionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuctions not implemented")
+}
+func (UnimplementedAuctionServer) ListBids(context.Context, *ListBidsRequest) (*ListBidsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBids not implemented")
+}
+func (UnimplementedAuctionServer) WatchAuction(*WatchRequest, Auction_WatchAuctionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAuction not implemented")
+}
+func (UnimplementedAuctionServer) BidSession(Auction_BidSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method BidSession not implemented")
+}
+func (UnimplementedAuctionServer) RetractBid(context.Context, *RetractRequest) (*RetractReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractBid not implemented")
+}
+func (UnimplementedAuctionServer) GetAccount(context.Context, *GetAccountRequest) (*AccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
 func (UnimplementedAuctionServer) mustEmbedUnimplementedAuctionServer() {}
 
 // UnsafeAuctionServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +247,125 @@ func _Auction_GetResult_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auction_ListAuctions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuctionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServer).ListAuctions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Auction/ListAuctions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServer).ListAuctions(ctx, req.(*ListAuctionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auction_ListBids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServer).ListBids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Auction/ListBids",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServer).ListBids(ctx, req.(*ListBidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auction_WatchAuction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuctionServer).WatchAuction(m, &auctionWatchAuctionServer{stream})
+}
+
+type Auction_WatchAuctionServer interface {
+	Send(*AuctionEvent) error
+	grpc.ServerStream
+}
+
+type auctionWatchAuctionServer struct {
+	grpc.ServerStream
+}
+
+func (x *auctionWatchAuctionServer) Send(m *AuctionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Auction_BidSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AuctionServer).BidSession(&auctionBidSessionServer{stream})
+}
+
+type Auction_BidSessionServer interface {
+	Send(*SessionMessage) error
+	Recv() (*SessionRequest, error)
+	grpc.ServerStream
+}
+
+type auctionBidSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *auctionBidSessionServer) Send(m *SessionMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *auctionBidSessionServer) Recv() (*SessionRequest, error) {
+	m := new(SessionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Auction_RetractBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServer).RetractBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Auction/RetractBid",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServer).RetractBid(ctx, req.(*RetractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auction_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Auction/GetAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auction_ServiceDesc is the grpc.ServiceDesc for Auction service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +381,1002 @@ var Auction_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetResult",
 			Handler:    _Auction_GetResult_Handler,
 		},
+		{
+			MethodName: "ListAuctions",
+			Handler:    _Auction_ListAuctions_Handler,
+		},
+		{
+			MethodName: "ListBids",
+			Handler:    _Auction_ListBids_Handler,
+		},
+		{
+			MethodName: "RetractBid",
+			Handler:    _Auction_RetractBid_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _Auction_GetAccount_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAuction",
+			Handler:       _Auction_WatchAuction_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BidSession",
+			Handler:       _Auction_BidSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "auction.proto",
+}
+
+// AuctionAdminClient is the client API for AuctionAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuctionAdminClient interface {
+	CreateAuction(ctx context.Context, in *CreateAuctionRequest, opts ...grpc.CallOption) (*CreateAuctionReply, error)
+	// Stops the clock of an open auction, no bids are accepted until it is resumed
+	PauseAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	// Opens a paused auction again, its end time moves by how long it was paused
+	ResumeAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	// Ends an open or paused auction right away
+	CloseAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	CancelAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	ExtendAuction(ctx context.Context, in *ExtendRequest, opts ...grpc.CallOption) (*AdminReply, error)
+	// Takes back a bid on behalf of a bidder, also after the retract window passed
+	RetractBid(ctx context.Context, in *RetractRequest, opts ...grpc.CallOption) (*RetractReply, error)
+	// Opens an account for a bidder in the currency of the amount, which is deposited
+	OpenAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error)
+	Deposit(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error)
+	// Fails if the funds are held for bids
+	Withdraw(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error)
+	// Sets how far the bidder may bid beyond their balance
+	SetCreditLimit(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error)
+	// Same as Auction.GetResult, for auctioneers who hold the admin token rather than a bidder token
+	GetResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultReply, error)
+	// Invoice of a settled auction and the ledger entries it was booked with, which show what every buyer paid
+	GetSettlement(ctx context.Context, in *SettlementRequest, opts ...grpc.CallOption) (*SettlementReply, error)
+	// Replicas coordinated by quorum book their own ledgers, the load balancer reads them from one replica
+	ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*ListLedgerEntriesReply, error)
+}
+
+type auctionAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuctionAdminClient(cc grpc.ClientConnInterface) AuctionAdminClient {
+	return &auctionAdminClient{cc}
+}
+
+func (c *auctionAdminClient) CreateAuction(ctx context.Context, in *CreateAuctionRequest, opts ...grpc.CallOption) (*CreateAuctionReply, error) {
+	out := new(CreateAuctionReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/CreateAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) PauseAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/PauseAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) ResumeAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/ResumeAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) CloseAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/CloseAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) CancelAuction(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/CancelAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) ExtendAuction(ctx context.Context, in *ExtendRequest, opts ...grpc.CallOption) (*AdminReply, error) {
+	out := new(AdminReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/ExtendAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) RetractBid(ctx context.Context, in *RetractRequest, opts ...grpc.CallOption) (*RetractReply, error) {
+	out := new(RetractReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/RetractBid", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) OpenAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error) {
+	out := new(AccountReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/OpenAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) Deposit(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error) {
+	out := new(AccountReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/Deposit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) Withdraw(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error) {
+	out := new(AccountReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/Withdraw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) SetCreditLimit(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*AccountReply, error) {
+	out := new(AccountReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/SetCreditLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) GetResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultReply, error) {
+	out := new(ResultReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/GetResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) GetSettlement(ctx context.Context, in *SettlementRequest, opts ...grpc.CallOption) (*SettlementReply, error) {
+	out := new(SettlementReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/GetSettlement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionAdminClient) ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*ListLedgerEntriesReply, error) {
+	out := new(ListLedgerEntriesReply)
+	err := c.cc.Invoke(ctx, "/AuctionAdmin/ListLedgerEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuctionAdminServer is the server API for AuctionAdmin service.
+// All implementations must embed UnimplementedAuctionAdminServer
+// for forward compatibility
+type AuctionAdminServer interface {
+	CreateAuction(context.Context, *CreateAuctionRequest) (*CreateAuctionReply, error)
+	// Stops the clock of an open auction, no bids are accepted until it is resumed
+	PauseAuction(context.Context, *AdminRequest) (*AdminReply, error)
+	// Opens a paused auction again, its end time moves by how long it was paused
+	ResumeAuction(context.Context, *AdminRequest) (*AdminReply, error)
+	// Ends an open or paused auction right away
+	CloseAuction(context.Context, *AdminRequest) (*AdminReply, error)
+	CancelAuction(context.Context, *AdminRequest) (*AdminReply, error)
+	ExtendAuction(context.Context, *ExtendRequest) (*AdminReply, error)
+	// Takes back a bid on behalf of a bidder, also after the retract window passed
+	RetractBid(context.Context, *RetractRequest) (*RetractReply, error)
+	// Opens an account for a bidder in the currency of the amount, which is deposited
+	OpenAccount(context.Context, *AccountRequest) (*AccountReply, error)
+	Deposit(context.Context, *AccountRequest) (*AccountReply, error)
+	// Fails if the funds are held for bids
+	Withdraw(context.Context, *AccountRequest) (*AccountReply, error)
+	// Sets how far the bidder may bid beyond their balance
+	SetCreditLimit(context.Context, *AccountRequest) (*AccountReply, error)
+	// Same as Auction.GetResult, for auctioneers who hold the admin token rather than a bidder token
+	GetResult(context.Context, *ResultRequest) (*ResultReply, error)
+	// Invoice of a settled auction and the ledger entries it was booked with, which show what every buyer paid
+	GetSettlement(context.Context, *SettlementRequest) (*SettlementReply, error)
+	// Replicas coordinated by quorum book their own ledgers, the load balancer reads them from one replica
+	ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*ListLedgerEntriesReply, error)
+	mustEmbedUnimplementedAuctionAdminServer()
+}
+
+// UnimplementedAuctionAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAuctionAdminServer struct {
+}
+
+func (UnimplementedAuctionAdminServer) CreateAuction(context.Context, *CreateAuctionRequest) (*CreateAuctionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuction not implemented")
+}
+func (UnimplementedAuctionAdminServer) PauseAuction(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseAuction not implemented")
+}
+func (UnimplementedAuctionAdminServer) ResumeAuction(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeAuction not implemented")
+}
+func (UnimplementedAuctionAdminServer) CloseAuction(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAuction not implemented")
+}
+func (UnimplementedAuctionAdminServer) CancelAuction(context.Context, *AdminRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAuction not implemented")
+}
+func (UnimplementedAuctionAdminServer) ExtendAuction(context.Context, *ExtendRequest) (*AdminReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendAuction not implemented")
+}
+func (UnimplementedAuctionAdminServer) RetractBid(context.Context, *RetractRequest) (*RetractReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractBid not implemented")
+}
+func (UnimplementedAuctionAdminServer) OpenAccount(context.Context, *AccountRequest) (*AccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenAccount not implemented")
+}
+func (UnimplementedAuctionAdminServer) Deposit(context.Context, *AccountRequest) (*AccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedAuctionAdminServer) Withdraw(context.Context, *AccountRequest) (*AccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
+}
+func (UnimplementedAuctionAdminServer) SetCreditLimit(context.Context, *AccountRequest) (*AccountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCreditLimit not implemented")
+}
+func (UnimplementedAuctionAdminServer) GetResult(context.Context, *ResultRequest) (*ResultReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
+}
+func (UnimplementedAuctionAdminServer) GetSettlement(context.Context, *SettlementRequest) (*SettlementReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettlement not implemented")
+}
+func (UnimplementedAuctionAdminServer) ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*ListLedgerEntriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLedgerEntries not implemented")
+}
+func (UnimplementedAuctionAdminServer) mustEmbedUnimplementedAuctionAdminServer() {}
+
+// UnsafeAuctionAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuctionAdminServer will
+// result in compilation errors.
+type UnsafeAuctionAdminServer interface {
+	mustEmbedUnimplementedAuctionAdminServer()
+}
+
+func RegisterAuctionAdminServer(s grpc.ServiceRegistrar, srv AuctionAdminServer) {
+	s.RegisterService(&AuctionAdmin_ServiceDesc, srv)
+}
+
+func _AuctionAdmin_CreateAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).CreateAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/CreateAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).CreateAuction(ctx, req.(*CreateAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_PauseAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).PauseAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/PauseAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).PauseAuction(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_ResumeAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).ResumeAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/ResumeAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).ResumeAuction(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_CloseAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).CloseAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/CloseAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).CloseAuction(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_CancelAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).CancelAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/CancelAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).CancelAuction(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_ExtendAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).ExtendAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/ExtendAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).ExtendAuction(ctx, req.(*ExtendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_RetractBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).RetractBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/RetractBid",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).RetractBid(ctx, req.(*RetractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_OpenAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).OpenAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/OpenAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).OpenAccount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/Deposit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).Deposit(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/Withdraw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).Withdraw(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_SetCreditLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).SetCreditLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/SetCreditLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).SetCreditLimit(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_GetResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).GetResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/GetResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).GetResult(ctx, req.(*ResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_GetSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).GetSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/GetSettlement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).GetSettlement(ctx, req.(*SettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionAdmin_ListLedgerEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLedgerEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionAdminServer).ListLedgerEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/AuctionAdmin/ListLedgerEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionAdminServer).ListLedgerEntries(ctx, req.(*ListLedgerEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuctionAdmin_ServiceDesc is the grpc.ServiceDesc for AuctionAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuctionAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "AuctionAdmin",
+	HandlerType: (*AuctionAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAuction",
+			Handler:    _AuctionAdmin_CreateAuction_Handler,
+		},
+		{
+			MethodName: "PauseAuction",
+			Handler:    _AuctionAdmin_PauseAuction_Handler,
+		},
+		{
+			MethodName: "ResumeAuction",
+			Handler:    _AuctionAdmin_ResumeAuction_Handler,
+		},
+		{
+			MethodName: "CloseAuction",
+			Handler:    _AuctionAdmin_CloseAuction_Handler,
+		},
+		{
+			MethodName: "CancelAuction",
+			Handler:    _AuctionAdmin_CancelAuction_Handler,
+		},
+		{
+			MethodName: "ExtendAuction",
+			Handler:    _AuctionAdmin_ExtendAuction_Handler,
+		},
+		{
+			MethodName: "RetractBid",
+			Handler:    _AuctionAdmin_RetractBid_Handler,
+		},
+		{
+			MethodName: "OpenAccount",
+			Handler:    _AuctionAdmin_OpenAccount_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _AuctionAdmin_Deposit_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _AuctionAdmin_Withdraw_Handler,
+		},
+		{
+			MethodName: "SetCreditLimit",
+			Handler:    _AuctionAdmin_SetCreditLimit_Handler,
+		},
+		{
+			MethodName: "GetResult",
+			Handler:    _AuctionAdmin_GetResult_Handler,
+		},
+		{
+			MethodName: "GetSettlement",
+			Handler:    _AuctionAdmin_GetSettlement_Handler,
+		},
+		{
+			MethodName: "ListLedgerEntries",
+			Handler:    _AuctionAdmin_ListLedgerEntries_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auction.proto",
+}
+
+// ReplicaClient is the client API for Replica service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplicaClient interface {
+	// Only taken from callers with a load balancer certificate, or the admin token over plaintext
+	Repair(ctx context.Context, in *RepairRequest, opts ...grpc.CallOption) (*RepairReply, error)
+	// Used by the primary to push every state update to the backups
+	Replicate(ctx context.Context, in *ReplicateRequest, opts ...grpc.CallOption) (*ReplicateReply, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatReply, error)
+	GetPrimary(ctx context.Context, in *PrimaryRequest, opts ...grpc.CallOption) (*PrimaryReply, error)
+	// Used by a recovering replica to catch up with a healthy one before it starts serving
+	FetchState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateReply, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
+}
+
+type replicaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicaClient(cc grpc.ClientConnInterface) ReplicaClient {
+	return &replicaClient{cc}
+}
+
+func (c *replicaClient) Repair(ctx context.Context, in *RepairRequest, opts ...grpc.CallOption) (*RepairReply, error) {
+	out := new(RepairReply)
+	err := c.cc.Invoke(ctx, "/Replica/Repair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaClient) Replicate(ctx context.Context, in *ReplicateRequest, opts ...grpc.CallOption) (*ReplicateReply, error) {
+	out := new(ReplicateReply)
+	err := c.cc.Invoke(ctx, "/Replica/Replicate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatReply, error) {
+	out := new(HeartbeatReply)
+	err := c.cc.Invoke(ctx, "/Replica/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaClient) GetPrimary(ctx context.Context, in *PrimaryRequest, opts ...grpc.CallOption) (*PrimaryReply, error) {
+	out := new(PrimaryReply)
+	err := c.cc.Invoke(ctx, "/Replica/GetPrimary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaClient) FetchState(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateReply, error) {
+	out := new(StateReply)
+	err := c.cc.Invoke(ctx, "/Replica/FetchState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error) {
+	out := new(StatusReply)
+	err := c.cc.Invoke(ctx, "/Replica/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplicaServer is the server API for Replica service.
+// All implementations must embed UnimplementedReplicaServer
+// for forward compatibility
+type ReplicaServer interface {
+	// Only taken from callers with a load balancer certificate, or the admin token over plaintext
+	Repair(context.Context, *RepairRequest) (*RepairReply, error)
+	// Used by the primary to push every state update to the backups
+	Replicate(context.Context, *ReplicateRequest) (*ReplicateReply, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatReply, error)
+	GetPrimary(context.Context, *PrimaryRequest) (*PrimaryReply, error)
+	// Used by a recovering replica to catch up with a healthy one before it starts serving
+	FetchState(context.Context, *StateRequest) (*StateReply, error)
+	Status(context.Context, *StatusRequest) (*StatusReply, error)
+	mustEmbedUnimplementedReplicaServer()
+}
+
+// UnimplementedReplicaServer must be embedded to have forward compatible implementations.
+type UnimplementedReplicaServer struct {
+}
+
+func (UnimplementedReplicaServer) Repair(context.Context, *RepairRequest) (*RepairReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Repair not implemented")
+}
+func (UnimplementedReplicaServer) Replicate(context.Context, *ReplicateRequest) (*ReplicateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (UnimplementedReplicaServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedReplicaServer) GetPrimary(context.Context, *PrimaryRequest) (*PrimaryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrimary not implemented")
+}
+func (UnimplementedReplicaServer) FetchState(context.Context, *StateRequest) (*StateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchState not implemented")
+}
+func (UnimplementedReplicaServer) Status(context.Context, *StatusRequest) (*StatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedReplicaServer) mustEmbedUnimplementedReplicaServer() {}
+
+// UnsafeReplicaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicaServer will
+// result in compilation errors.
+type UnsafeReplicaServer interface {
+	mustEmbedUnimplementedReplicaServer()
+}
+
+func RegisterReplicaServer(s grpc.ServiceRegistrar, srv ReplicaServer) {
+	s.RegisterService(&Replica_ServiceDesc, srv)
+}
+
+func _Replica_Repair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).Repair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Replica/Repair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).Repair(ctx, req.(*RepairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replica_Replicate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).Replicate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Replica/Replicate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).Replicate(ctx, req.(*ReplicateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replica_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Replica/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replica_GetPrimary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrimaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).GetPrimary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Replica/GetPrimary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).GetPrimary(ctx, req.(*PrimaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replica_FetchState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).FetchState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Replica/FetchState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).FetchState(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replica_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Replica/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Replica_ServiceDesc is the grpc.ServiceDesc for Replica service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Replica_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Replica",
+	HandlerType: (*ReplicaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Repair",
+			Handler:    _Replica_Repair_Handler,
+		},
+		{
+			MethodName: "Replicate",
+			Handler:    _Replica_Replicate_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Replica_Heartbeat_Handler,
+		},
+		{
+			MethodName: "GetPrimary",
+			Handler:    _Replica_GetPrimary_Handler,
+		},
+		{
+			MethodName: "FetchState",
+			Handler:    _Replica_FetchState_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Replica_Status_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auction.proto",
+}
+
+// RaftClient is the client API for Raft service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RaftClient interface {
+	RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteReply, error)
+	AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error)
+}
+
+type raftClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRaftClient(cc grpc.ClientConnInterface) RaftClient {
+	return &raftClient{cc}
+}
+
+func (c *raftClient) RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteReply, error) {
+	out := new(VoteReply)
+	err := c.cc.Invoke(ctx, "/Raft/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftClient) AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error) {
+	out := new(AppendEntriesReply)
+	err := c.cc.Invoke(ctx, "/Raft/AppendEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RaftServer is the server API for Raft service.
+// All implementations must embed UnimplementedRaftServer
+// for forward compatibility
+type RaftServer interface {
+	RequestVote(context.Context, *VoteRequest) (*VoteReply, error)
+	AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesReply, error)
+	mustEmbedUnimplementedRaftServer()
+}
+
+// UnimplementedRaftServer must be embedded to have forward compatible implementations.
+type UnimplementedRaftServer struct {
+}
+
+func (UnimplementedRaftServer) RequestVote(context.Context, *VoteRequest) (*VoteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedRaftServer) AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (UnimplementedRaftServer) mustEmbedUnimplementedRaftServer() {}
+
+// UnsafeRaftServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RaftServer will
+// result in compilation errors.
+type UnsafeRaftServer interface {
+	mustEmbedUnimplementedRaftServer()
+}
+
+func RegisterRaftServer(s grpc.ServiceRegistrar, srv RaftServer) {
+	s.RegisterService(&Raft_ServiceDesc, srv)
+}
+
+func _Raft_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Raft/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServer).RequestVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Raft_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Raft/AppendEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServer).AppendEntries(ctx, req.(*AppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Raft_ServiceDesc is the grpc.ServiceDesc for Raft service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Raft_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Raft",
+	HandlerType: (*RaftServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestVote",
+			Handler:    _Raft_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _Raft_AppendEntries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auction.proto",
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"github.com/ap/DMP3/internal/certs"
+	"github.com/ap/DMP3/internal/ledger"
+	"github.com/ap/DMP3/internal/logging"
+	"github.com/ap/DMP3/internal/money"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata key the admin token is sent in
+const adminTokenKey = "admin-token"
+
+var (
+	serverAddr    = flag.String("serverAddr", "localhost:5000", "Load balancer to connect to")
+	token         = flag.String("token", os.Getenv("ADMINTOKEN"), "Admin token, defaults to $ADMINTOKEN")
+	item          = flag.String("item", "", "Item an auction is created for")
+	duration      = flag.Duration("duration", 0, "How long a created auction runs, the load balancer default if 0")
+	start         = flag.String("start", "", "RFC 3339 time a created auction opens, right away if empty")
+	auctionType   = flag.String("type", "english", "Type of a created auction: english, vickrey or dutch")
+	currency      = flag.String("currency", money.DefaultCurrency, "ISO 4217 code of the currency of a created auction, the prices are in minor units of it")
+	reserve       = flag.Int64("reserve", 0, "Hidden reserve price of a created auction")
+	startingPrice = flag.Int64("startingPrice", 0, "Lowest first bid of a created auction")
+	minIncrement  = flag.Int64("minIncrement", 0, "Minimum increment of a created auction")
+	dutchStart    = flag.Int64("dutchStart", 0, "Asking price a Dutch auction starts at")
+	dutchStep     = flag.Int64("dutchStep", 0, "How much the asking price of a Dutch auction drops every interval")
+	dutchInterval = flag.Duration("dutchInterval", time.Second, "How often the asking price of a Dutch auction drops")
+	dutchFloor    = flag.Int64("dutchFloor", 0, "Asking price a Dutch auction stops dropping at")
+	softWindow    = flag.Duration("softWindow", 0, "Bids this close to the end extend a created auction, no soft close if 0")
+	softExtension = flag.Duration("softExtension", 0, "How much a late bid extends a created auction")
+	hardClose     = flag.String("hardClose", "", "RFC 3339 time a created auction is never extended past")
+	retractWindow = flag.Duration("retractWindow", 0, "How long bidders may retract their bids in a created auction, not at all if 0")
+	by            = flag.Duration("by", 0, "How much extend moves the end time")
+	until         = flag.String("until", "", "RFC 3339 time extend moves the end time to, instead of --by")
+	bidder        = flag.String("bidder", "", "Bidder whose bid retract takes back")
+	requestID     = flag.String("requestId", "", "Request id of the bid retract takes back, the latest accepted bid of the bidder if empty")
+	reason        = flag.String("reason", "", "Why retract takes the bid back, kept in the bid history")
+	seller        = flag.String("seller", "", "Who is paid when a created auction sells, the house if empty")
+	feeBps        = flag.Int("feeBps", 0, "Platform fee of a created auction in basis points, the replica default if 0")
+	authSecret    = flag.String("authSecret", os.Getenv("AUTHSECRET"), "Secret issue-token signs with, the --authSecret of the load balancer. Defaults to $AUTHSECRET")
+	ttl           = flag.Duration("ttl", 24*time.Hour, "How long an issued token is valid, forever if 0")
+	tlsCA         = flag.String("tlsCA", "", "CA that signs the certificate of the load balancer, plaintext if empty")
+	tlsCert       = flag.String("tlsCert", "", "Client certificate for load balancers that require one")
+	tlsKey        = flag.String("tlsKey", "", "Key of --tlsCert")
+	certsDir      = flag.String("dir", "certs", "Directory certs generates the certificates in, the CA in it is reused if there is one")
+	hosts         = flag.String("hosts", "localhost,127.0.0.1", "Comma separated names and addresses the load balancer certificate generated by certs is valid for")
+	replicas      = flag.String("replicas", "localhost", "Comma separated name or address of each replica, certs generates a certificate for each valid for just its host")
+	validity      = flag.Duration("validity", 365*24*time.Hour, "How long the certificates generated by certs are valid")
+	amount        = flag.String("amount", "0", "Amount in --currency an account is opened with, deposited, withdrawn or its credit limit set to, like 12.50")
+	logger        = logging.New()
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <create|pause|resume|close|cancel|extend|retract> <auction>\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "       %s [flags] <open-account|deposit|withdraw|credit-limit> <bidder>\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "       %s [flags] <settlement|ledger> <auction>, ledger all lists and verifies the whole ledger\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "       %s [flags] issue-token <bidder>\n", os.Args[0])
+	fmt.Fprintf(flag.CommandLine.Output(), "       %s [flags] certs, generates a CA and certificates for a dev cluster in --dir\n", os.Args[0])
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	// The only command without an argument
+	if flag.Arg(0) == "certs" {
+		if flag.NArg() != 1 {
+			usage()
+			os.Exit(2)
+		}
+		if err := certs.Generate(*certsDir, strings.Split(*hosts, ","), strings.Split(*replicas, ","), *validity); err != nil {
+			logger.EPrintf("Failed to generate certificates in %s: %v\n", *certsDir, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.NArg() != 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, auctionID := flag.Arg(0), flag.Arg(1)
+
+	// Tokens are signed right here, the load balancer only has to know the secret
+	if command == "issue-token" {
+		if err := issueToken(auctionID); err != nil {
+			logger.EPrintf("Failed to issue a token for %s: %v\n", auctionID, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	transport := grpc.WithInsecure()
+	if len(*tlsCA) > 0 {
+		reloader, err := certs.NewReloader(*tlsCert, *tlsKey, *tlsCA)
+		if err != nil {
+			logger.EPrintf("Failed to load the certificates: %v\n", err)
+			os.Exit(1)
+		}
+		transport = grpc.WithTransportCredentials(reloader.ClientCredentials())
+	}
+
+	conn, err := grpc.Dial(*serverAddr, transport, grpc.WithBlock(), grpc.WithTimeout(5*time.Second))
+	if err != nil {
+		logger.EPrintf("Could not connect to %s: %v\n", *serverAddr, err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	c := pb.NewAuctionAdminClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, adminTokenKey, *token)
+
+	var reply *pb.AdminReply
+	switch command {
+	case "create":
+		err = create(c, ctx, auctionID)
+	case "pause":
+		reply, err = c.PauseAuction(ctx, &pb.AdminRequest{AuctionId: auctionID})
+	case "resume":
+		reply, err = c.ResumeAuction(ctx, &pb.AdminRequest{AuctionId: auctionID})
+	case "close":
+		reply, err = c.CloseAuction(ctx, &pb.AdminRequest{AuctionId: auctionID})
+	case "cancel":
+		reply, err = c.CancelAuction(ctx, &pb.AdminRequest{AuctionId: auctionID})
+	case "extend":
+		reply, err = extend(c, ctx, auctionID)
+	case "retract":
+		err = retract(c, ctx, auctionID)
+	case "open-account", "deposit", "withdraw", "credit-limit":
+		err = account(c, ctx, command, auctionID)
+	case "settlement":
+		err = settlement(c, ctx, auctionID)
+	case "ledger":
+		err = listLedger(c, ctx, auctionID)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.EPrintf("Failed to %s %s: %v\n", command, auctionID, err)
+		os.Exit(1)
+	}
+
+	if reply != nil {
+		printReply(command, auctionID, reply)
+		if reply.Outcome != pb.AdminReply_SUCCESS {
+			os.Exit(1)
+		}
+	}
+}
+
+func create(c pb.AuctionAdminClient, ctx context.Context, auctionID string) error {
+	code := strings.ToUpper(*currency)
+	request := &pb.CreateAuctionRequest{
+		AuctionId:           auctionID,
+		Item:                *item,
+		DurationSeconds:     int32(duration.Seconds()),
+		RetractWindowMs:     retractWindow.Milliseconds(),
+		Currency:            code,
+		Seller:              *seller,
+		FeeBps:              int32(*feeBps),
+		MinIncrementAmount:  money.New(*minIncrement, code),
+		ReservePriceAmount:  money.New(*reserve, code),
+		StartingPriceAmount: money.New(*startingPrice, code),
+	}
+
+	switch strings.ToLower(*auctionType) {
+	case "english":
+		request.Type = pb.AuctionType_ENGLISH
+	case "vickrey":
+		request.Type = pb.AuctionType_VICKREY
+	case "dutch":
+		request.Type = pb.AuctionType_DUTCH
+		request.Dutch = &pb.DutchSchedule{
+			StepIntervalMs:   dutchInterval.Milliseconds(),
+			StartPriceAmount: money.New(*dutchStart, code),
+			PriceStepAmount:  money.New(*dutchStep, code),
+			FloorPriceAmount: money.New(*dutchFloor, code),
+		}
+	default:
+		return fmt.Errorf("unknown auction type %q", *auctionType)
+	}
+
+	if len(*start) > 0 {
+		startTime, err := time.Parse(time.RFC3339, *start)
+		if err != nil {
+			return err
+		}
+		request.StartTime = startTime.UnixMilli()
+	}
+
+	if *softWindow > 0 {
+		request.SoftClose = &pb.SoftClose{
+			WindowMs:    softWindow.Milliseconds(),
+			ExtensionMs: softExtension.Milliseconds(),
+		}
+		if len(*hardClose) > 0 {
+			hardCloseTime, err := time.Parse(time.RFC3339, *hardClose)
+			if err != nil {
+				return err
+			}
+			request.SoftClose.HardCloseTime = hardCloseTime.UnixMilli()
+		}
+	}
+
+	reply, err := c.CreateAuction(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if reply.Outcome != pb.CreateAuctionReply_SUCCESS {
+		return fmt.Errorf("replicas answered %s", reply.Outcome)
+	}
+
+	logger.IPrintf("Created %s auction %s in %s\n", request.Type, auctionID, request.Currency)
+
+	return nil
+}
+
+/*
+Moves the end time to the given time, or by the given duration from the
+current end time, which is looked up first
+*/
+func extend(c pb.AuctionAdminClient, ctx context.Context, auctionID string) (*pb.AdminReply, error) {
+	var endTime time.Time
+	if len(*until) > 0 {
+		parsed, err := time.Parse(time.RFC3339, *until)
+		if err != nil {
+			return nil, err
+		}
+		endTime = parsed
+	} else if *by > 0 {
+		current, err := c.GetResult(ctx, &pb.ResultRequest{AuctionId: auctionID})
+		if err != nil {
+			return nil, err
+		}
+		endTime = time.UnixMilli(current.EndTime).Add(*by)
+	} else {
+		return nil, fmt.Errorf("extend needs --by or --until")
+	}
+
+	return c.ExtendAuction(ctx, &pb.ExtendRequest{
+		AuctionId: auctionID,
+		EndTime:   endTime.UnixMilli(),
+	})
+}
+
+// Takes back a bid of the bidder, the auctioneer is not held to the retract window
+func retract(c pb.AuctionAdminClient, ctx context.Context, auctionID string) error {
+	if len(*bidder) == 0 {
+		return fmt.Errorf("retract needs --bidder")
+	}
+
+	reply, err := c.RetractBid(ctx, &pb.RetractRequest{
+		AuctionId: auctionID,
+		Bidder:    *bidder,
+		RequestId: *requestID,
+		Reason:    *reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	if reply.Outcome != pb.RetractReply_SUCCESS {
+		return fmt.Errorf("replicas answered %s", reply.Outcome)
+	}
+
+	result := reply.GetResult()
+	logger.IPrintf("Retracted the bid of %s in %s\n", *bidder, auctionID)
+	if len(result.GetWinner()) > 0 {
+		logger.IPrintf("Highest bid is now %s by %s\n", money.String(result.GetResultAmount()), result.GetWinner())
+	} else {
+		logger.IPrintf("The auction has no bids left\n")
+	}
+
+	return nil
+}
+
+/*
+Opens the account of a bidder or changes its funds. The bidder is given
+where the other commands take the auction
+*/
+func account(c pb.AuctionAdminClient, ctx context.Context, command string, bidder string) error {
+	code := strings.ToUpper(*currency)
+	units, err := money.Parse(*amount, code)
+	if err != nil {
+		return fmt.Errorf("invalid --amount %q: %v", *amount, err)
+	}
+
+	request := &pb.AccountRequest{
+		Bidder: bidder,
+		Amount: money.New(units, code),
+	}
+
+	var reply *pb.AccountReply
+	switch command {
+	case "open-account":
+		reply, err = c.OpenAccount(ctx, request)
+	case "deposit":
+		reply, err = c.Deposit(ctx, request)
+	case "withdraw":
+		reply, err = c.Withdraw(ctx, request)
+	case "credit-limit":
+		reply, err = c.SetCreditLimit(ctx, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	if reply.Outcome != pb.AccountReply_SUCCESS {
+		return fmt.Errorf("replicas answered %s", reply.Outcome)
+	}
+
+	info := reply.GetAccount()
+	logger.IPrintf("Account of %s has a balance of %s and a credit limit of %s\n", bidder, money.String(info.GetBalance()), money.String(info.GetCreditLimit()))
+	logger.IPrintf("%s is held, %s is available\n", money.String(info.GetHeld()), money.String(info.GetAvailable()))
+
+	return nil
+}
+
+// Shows the invoice of a settled auction and the entries it was booked with
+func settlement(c pb.AuctionAdminClient, ctx context.Context, auctionID string) error {
+	reply, err := c.GetSettlement(ctx, &pb.SettlementRequest{AuctionId: auctionID})
+	if err != nil {
+		return err
+	}
+
+	if reply.Outcome != pb.SettlementReply_SUCCESS {
+		return fmt.Errorf("replicas answered %s", reply.Outcome)
+	}
+
+	invoice := reply.GetInvoice()
+	settledAt := time.UnixMilli(invoice.GetSettledAt()).Format(time.RFC3339)
+	if !invoice.GetSold() {
+		logger.IPrintf("Invoice %s: %q was not sold, settled at %s\n", invoice.GetInvoiceId(), invoice.GetItem(), settledAt)
+		return nil
+	}
+
+	logger.IPrintf("Invoice %s: %q sold to %s by %s for %s, settled at %s\n", invoice.GetInvoiceId(), invoice.GetItem(), invoice.GetBuyer(), invoice.GetSeller(), money.String(invoice.GetPrice()), settledAt)
+	logger.IPrintf("Fee of %d bps is %s, the seller gets %s\n", invoice.GetFeeBps(), money.String(invoice.GetFee()), money.String(invoice.GetProceeds()))
+	for _, entry := range reply.GetEntries() {
+		printEntry(entry)
+	}
+
+	return nil
+}
+
+/*
+Lists the ledger entries of the auction, or the whole ledger for all. The
+whole ledger is verified here as well, so a replica cannot hide a broken
+chain by claiming it verified
+*/
+func listLedger(c pb.AuctionAdminClient, ctx context.Context, auctionID string) error {
+	filter := auctionID
+	if auctionID == "all" {
+		filter = ""
+	}
+
+	var entries []*pb.LedgerEntry
+	var reply *pb.ListLedgerEntriesReply
+	for from := uint64(1); from > 0; from = reply.GetNextSeq() {
+		var err error
+		reply, err = c.ListLedgerEntries(ctx, &pb.ListLedgerEntriesRequest{
+			AuctionId: filter,
+			FromSeq:   from,
+		})
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, reply.GetEntries()...)
+	}
+
+	for _, entry := range entries {
+		printEntry(entry)
+	}
+
+	if !reply.GetVerified() {
+		return fmt.Errorf("the replica could not verify its ledger")
+	}
+
+	if len(filter) == 0 {
+		if err := ledger.Verify(entries); err != nil {
+			return fmt.Errorf("ledger does not verify: %v", err)
+		}
+		if len(entries) > 0 && entries[len(entries)-1].GetHash() != reply.GetHeadHash() {
+			return fmt.Errorf("ledger does not end at head %s", reply.GetHeadHash())
+		}
+	}
+
+	logger.IPrintf("%d entries, ledger verified up to head %s\n", len(entries), reply.GetHeadHash())
+	return nil
+}
+
+func printEntry(entry *pb.LedgerEntry) {
+	logger.IPrintf("#%d %s %s %-6s %s %s\n", entry.GetSeq(), entry.GetInvoiceId(), entry.GetAccount(), entry.GetSide(), money.String(entry.GetAmount()), entry.GetMemo())
+}
+
+// Prints a token the bidder can bid with, nothing else is printed so it can be captured
+func issueToken(bidder string) error {
+	if len(*authSecret) == 0 {
+		return fmt.Errorf("issue-token needs --authSecret")
+	}
+
+	token, err := auth.Issue([]byte(*authSecret), bidder, *ttl, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token)
+	return nil
+}
+
+func printReply(command string, auctionID string, reply *pb.AdminReply) {
+	auction := reply.GetAuction()
+	if reply.Outcome != pb.AdminReply_SUCCESS {
+		logger.EPrintf("Could not %s %s: %s, auction is %s\n", command, auctionID, reply.Outcome, auction.GetState())
+		return
+	}
+
+	logger.IPrintf("Auction %s is now %s\n", auctionID, auction.GetState())
+	if auction.GetEndTime() > 0 {
+		logger.IPrintf("Auction ends at %s\n", time.UnixMilli(auction.GetEndTime()).Format(time.RFC3339))
+	}
+}
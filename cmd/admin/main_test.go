@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Answers the admin calls the commands make, as the load balancer would
+type fakeAdmin struct {
+	pb.UnimplementedAuctionAdminServer
+}
+
+func (fakeAdmin) GetResult(context.Context, *pb.ResultRequest) (*pb.ResultReply, error) {
+	return &pb.ResultReply{EndTime: time.Now().UnixMilli()}, nil
+}
+
+func (fakeAdmin) ExtendAuction(context.Context, *pb.ExtendRequest) (*pb.AdminReply, error) {
+	return &pb.AdminReply{}, nil
+}
+
+func (fakeAdmin) GetSettlement(context.Context, *pb.SettlementRequest) (*pb.SettlementReply, error) {
+	return &pb.SettlementReply{Invoice: &pb.Invoice{}}, nil
+}
+
+func (fakeAdmin) ListLedgerEntries(context.Context, *pb.ListLedgerEntriesRequest) (*pb.ListLedgerEntriesReply, error) {
+	return &pb.ListLedgerEntriesReply{Verified: true}, nil
+}
+
+// Bidders must authenticate, so a command calling this instead of the admin service fails
+type fakeAuction struct {
+	pb.UnimplementedAuctionServer
+}
+
+func (fakeAuction) GetResult(context.Context, *pb.ResultRequest) (*pb.ResultReply, error) {
+	return &pb.ResultReply{}, nil
+}
+
+// Serves both services with bidder authentication enabled, like a load balancer with --authSecret
+func serve(t *testing.T) pb.AuctionAdminClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	secret := []byte("secret")
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(auth.UnaryInterceptor(secret, "Auction")),
+		grpc.StreamInterceptor(auth.StreamInterceptor(secret, "Auction")))
+	pb.RegisterAuctionServer(server, fakeAuction{})
+	pb.RegisterAuctionAdminServer(server, fakeAdmin{})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewAuctionAdminClient(conn)
+}
+
+// Auctioneers only hold the admin token, every command must work without a bidder token
+func TestCommandsWithAuth(t *testing.T) {
+	c := serve(t)
+	*by = time.Minute
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{name: "extend", run: func(ctx context.Context) error {
+			_, err := extend(c, ctx, "default")
+			return err
+		}},
+		{name: "settlement", run: func(ctx context.Context) error {
+			return settlement(c, ctx, "default")
+		}},
+		{name: "ledger", run: func(ctx context.Context) error {
+			return listLedger(c, ctx, "default")
+		}},
+		{name: "ledger all", run: func(ctx context.Context) error {
+			return listLedger(c, ctx, "all")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			ctx = metadata.AppendToOutgoingContext(ctx, adminTokenKey, "token")
+
+			if err := test.run(ctx); err != nil {
+				t.Errorf("%s error = %v", test.name, err)
+			}
+		})
+	}
+}
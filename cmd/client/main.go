@@ -2,29 +2,72 @@ package main
 
 import (
 	"context"
+	crand "crypto/rand"
+	"encoding/hex"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"time"
 
 	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"github.com/ap/DMP3/internal/certs"
 	"github.com/ap/DMP3/internal/logging"
+	"github.com/ap/DMP3/internal/money"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	serverAddr = flag.String("serverAddr", "localhost:5001", "Server to connect to")
 	random     = flag.Bool("random", false, "Randomly send data")
-	logger     = logging.New()
+	bidder     = flag.String("bidder", "", "Identity to bid as, defaults to the hostname")
+	auctionID  = flag.String("auction", "", "Auction to take part in, defaults to the default auction")
+	retries    = flag.Int("retries", 3, "Number of times a bid is retried when its outcome is unknown")
+	maxBid     = flag.Int64("maxBid", 0, "Secret maximum, in minor units, the replicas keep bidding up to on your behalf, no proxy bidding if 0")
+	watchOnly  = flag.Bool("watch", false, "Only print the events of the auction as they happen, until it is settled")
+	token      = flag.String("token", os.Getenv("BIDDERTOKEN"), "Token the auctioneer issued to the bidder, needed if the load balancer authenticates bidders. Defaults to $BIDDERTOKEN")
+	tlsCA      = flag.String("tlsCA", "", "CA that signs the certificate of the load balancer, plaintext if empty")
+	tlsCert    = flag.String("tlsCert", "", "Client certificate for load balancers that require one")
+	tlsKey     = flag.String("tlsKey", "", "Key of --tlsCert")
+	useSession = flag.Bool("session", false, "With --random, bid over a single bidding session and only bid again when outbid")
+	// Request id of the last bid accepted, the one a retraction takes back
+	lastBid string
+	// Currency of the auction, learned from its result
+	currency string
+	logger   = logging.New()
 )
 
 func main() {
 	flag.Parse()
 
+	if len(*bidder) == 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.EPrintf("Could not determine hostname, use --bidder: %v\n", err)
+			return
+		}
+		*bidder = hostname
+	}
+
+	logger.IPrintf("Bidding as %s\n", *bidder)
 	logger.IPrintf("Dialing %s\n", *serverAddr)
 
-	conn, err := grpc.Dial(*serverAddr, grpc.WithInsecure(), grpc.WithBlock())
+	transport := grpc.WithInsecure()
+	if len(*tlsCA) > 0 {
+		reloader, err := certs.NewReloader(*tlsCert, *tlsKey, *tlsCA)
+		if err != nil {
+			logger.EPrintf("Failed to load the certificates: %v\n", err)
+			return
+		}
+		transport = grpc.WithTransportCredentials(reloader.ClientCredentials())
+	}
+
+	conn, err := grpc.Dial(*serverAddr, transport, grpc.WithBlock())
 	if err != nil {
 		logger.EPrintf("Could not connect: %v\n", err)
 	}
@@ -34,7 +77,15 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	if *random {
+	if len(*token) > 0 {
+		ctx = auth.WithToken(ctx, *token)
+	}
+
+	if *watchOnly {
+		watch(c, ctx)
+	} else if *random && *useSession {
+		sessionAuction(c, ctx)
+	} else if *random {
 		autoAuction(c, ctx)
 	} else {
 		auction(c, ctx)
@@ -45,24 +96,161 @@ func autoAuction(c pb.AuctionClient, ctx context.Context) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	for {
-		result, err := result(c, ctx)
+		current, err := result(c, ctx)
 		if err != nil {
 			break
 		}
 
-		result += r.Int31n(10)
-		err = bid(result, c, ctx)
-		if err != nil {
+		if current.AuctionState != pb.AuctionState_SCHEDULED && current.AuctionState != pb.AuctionState_OPEN && current.AuctionState != pb.AuctionState_PAUSED {
+			logger.IPrintf("Auction is %s, stopping\n", current.AuctionState)
 			break
 		}
+
+		if current.AuctionState == pb.AuctionState_OPEN && current.AskingPriceAmount != nil {
+			// Dutch auction, accept the asking price at some point while it drops
+			if r.Intn(4) == 0 {
+				err = bid(current.AskingPriceAmount.MinorUnits, *bidder, c, ctx)
+				if err != nil {
+					break
+				}
+			}
+		} else if current.AuctionState == pb.AuctionState_OPEN && current.Winner != *bidder {
+			amount := money.Result(current) + r.Int63n(10)
+			if *maxBid > 0 {
+				// The replicas raise the bid up to the maximum, so the lowest bid possible is enough
+				amount = money.Result(current) + 1
+			}
+
+			err = bid(amount, *bidder, c, ctx)
+			if err != nil {
+				break
+			}
+		}
 		time.Sleep(time.Duration(r.Intn(10)) * time.Second)
 	}
 }
 
+/*
+Bids over a bidding session, the load balancer says when we are outbid so
+there is no need to poll. The session is closed once the auction is over
+*/
+func sessionAuction(c pb.AuctionClient, ctx context.Context) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	current, err := result(c, ctx)
+	if err != nil {
+		return
+	}
+
+	stream, err := c.BidSession(ctx)
+	if err != nil {
+		logger.EPrintf("Failed to open a bidding session: %v\n", err)
+		return
+	}
+
+	messages := make(chan *pb.SessionMessage)
+	go func() {
+		defer close(messages)
+		for {
+			message, err := stream.Recv()
+			if err == io.EOF {
+				logger.IPrintf("Bidding session closed\n")
+				return
+			} else if err != nil {
+				logger.EPrintf("Lost the bidding session: %v\n", err)
+				return
+			}
+			messages <- message
+		}
+	}()
+
+	over := make(chan struct{})
+	go func() {
+		defer close(over)
+		watcher, err := c.WatchAuction(ctx, &pb.WatchRequest{AuctionId: *auctionID})
+		if err != nil {
+			return
+		}
+		for {
+			event, err := watcher.Recv()
+			if err != nil {
+				return
+			}
+
+			switch event.GetResult().GetAuctionState() {
+			case pb.AuctionState_SCHEDULED, pb.AuctionState_OPEN, pb.AuctionState_PAUSED:
+			default:
+				return
+			}
+		}
+	}()
+
+	next := 0
+	send := func(amount int64) error {
+		next++
+		logger.IPrintf("Bidding %s as %s on the session\n", money.Format(amount, currency), *bidder)
+
+		return stream.Send(&pb.SessionRequest{
+			CorrelationId: fmt.Sprint(next),
+			Bid:           newBidRequest(amount, *bidder, ""),
+		})
+	}
+
+	if err := send(money.Result(current) + 1 + r.Int63n(10)); err != nil {
+		logger.EPrintf("Failed to bid: %v\n", err)
+		return
+	}
+
+	for {
+		select {
+		case <-over:
+			// Let the session answer anything still in flight and end
+			logger.IPrintf("Auction is over, closing the bidding session\n")
+			stream.CloseSend()
+			over = nil
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			switch m := message.Message.(type) {
+			case *pb.SessionMessage_Reply:
+				logger.IPrintf("Bid %s: %s %s\n", message.CorrelationId, outcomeToString(m.Reply.Outcome), m.Reply.Reason)
+				if m.Reply.Outcome != pb.BidReply_FAIL || over == nil {
+					continue
+				}
+
+				// Someone else bid more in the meantime
+				current, err := result(c, ctx)
+				if err != nil {
+					return
+				}
+				if err := send(money.Result(current) + 1 + r.Int63n(10)); err != nil {
+					logger.EPrintf("Failed to bid: %v\n", err)
+					return
+				}
+			case *pb.SessionMessage_Outbid:
+				highest := m.Outbid.GetResult()
+				logger.IPrintf("Outbid by %s with %s\n", highest.Winner, money.String(highest.ResultAmount))
+				if over == nil {
+					continue
+				}
+
+				time.Sleep(time.Duration(r.Intn(1000)) * time.Millisecond)
+				if err := send(money.Result(highest) + 1 + r.Int63n(10)); err != nil {
+					logger.EPrintf("Failed to bid: %v\n", err)
+					return
+				}
+			}
+		}
+	}
+}
+
 func auction(c pb.AuctionClient, ctx context.Context) {
 	for {
-		var choice, toBid int32
-		logger.IPrintf("Do you want to get the result (1) or bid (2): ")
+		var choice int32
+		var toBid string
+		logger.IPrintf("Do you want to get the result (1), bid (2), retract your last bid (3) or see your account (4): ")
 		if _, err := fmt.Scanf("%d\n", &choice); err != nil {
 			logger.EPrintf("Invalid input, dying: %v\n", err)
 		}
@@ -75,17 +263,35 @@ func auction(c pb.AuctionClient, ctx context.Context) {
 			}
 			break
 		case 2:
-			logger.IPrintf("How much do you want to bet?\n> ")
+			if len(currency) == 0 {
+				if _, err := result(c, ctx); err != nil {
+					return
+				}
+			}
 
-			if _, err := fmt.Scanf("%d\n", &toBid); err != nil {
+			logger.IPrintf("How much do you want to bet, in %s?\n> ", currency)
+
+			if _, err := fmt.Scanf("%s\n", &toBid); err != nil {
 				logger.EPrintf("Invalid input, dying: %v\n", err)
 			}
 
-			err := bid(toBid, c, ctx)
+			amount, err := money.Parse(toBid, currency)
+			if err != nil {
+				logger.EPrintf("Invalid amount %q: %v\n", toBid, err)
+				break
+			}
+
+			err = bid(amount, *bidder, c, ctx)
 			if err != nil {
 				return
 			}
 
+			break
+		case 3:
+			retract(c, ctx)
+			break
+		case 4:
+			account(c, ctx)
 			break
 		default:
 			logger.EPrintf("Invalid choice\n")
@@ -95,39 +301,229 @@ func auction(c pb.AuctionClient, ctx context.Context) {
 	}
 }
 
-func bid(amount int32, c pb.AuctionClient, ctx context.Context) error {
-	logger.IPrintf("Bidding %d\n", amount)
+/*
+Whether a call that failed with the error may succeed when made again. A
+rejected token or request fails the same way every time
+*/
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
 
-	reply, err := c.Bid(ctx, &pb.BidRequest{
-		Bid: amount,
-	})
+/*
+Places a bid, retrying it while its outcome is unknown. Every attempt carries
+the same request id, so a bid that was applied before the reply got lost is
+not applied twice
+*/
+func bid(amount int64, bidder string, c pb.AuctionClient, ctx context.Context) error {
+	requestID, err := newRequestID()
+	if err != nil {
+		logger.EPrintf("Failed to generate a request id: %v\n", err)
+		return err
+	}
+
+	logger.IPrintf("Bidding %s as %s with request id %s\n", money.Format(amount, currency), bidder, requestID)
+
+	var reply *pb.BidReply
+	for attempt := 0; attempt <= *retries; attempt++ {
+		if attempt > 0 {
+			logger.IPrintf("Retrying bid %s, attempt %d of %d\n", requestID, attempt, *retries)
+			time.Sleep(time.Duration(attempt) * 200 * time.Millisecond)
+		}
+
+		reply, err = c.Bid(ctx, newBidRequest(amount, bidder, requestID))
+
+		if (err == nil && reply.Outcome != pb.BidReply_UNAVAILABLE) || (err != nil && !retryable(err)) {
+			break
+		}
+	}
 
 	if err != nil {
 		logger.EPrintf("Failed to bid: %v\n", err)
 		return err
-	} else if reply.Outcome == pb.BidReply_FAIL || reply.Outcome == pb.BidReply_EXCEPTION {
+	} else if reply.Outcome != pb.BidReply_SUCCESS {
 		msg := fmt.Sprintf("Bid failed with outcome: %s", outcomeToString(reply.Outcome))
+		if reply.Reason != pb.BidReply_NONE {
+			msg = fmt.Sprintf("%s (%s)", msg, reply.Reason)
+		}
 		logger.EPrintf("%s\n", msg)
 
 		return errors.New(msg)
 	}
 
-	logger.IPrintf("Successfully bid %d\n", amount)
+	logger.IPrintf("Successfully bid %s\n", money.Format(amount, currency))
+	lastBid = requestID
 
 	return nil
 }
 
-func result(c pb.AuctionClient, ctx context.Context) (int32, error) {
+// A bid in the currency of the auction, with the secret maximum if one was given
+func newBidRequest(amount int64, bidder string, requestID string) *pb.BidRequest {
+	request := &pb.BidRequest{
+		BidAmount: money.New(amount, currency),
+		Bidder:    bidder,
+		AuctionId: *auctionID,
+		RequestId: requestID,
+	}
+	if *maxBid > 0 {
+		request.MaxBidAmount = money.New(*maxBid, currency)
+	}
+
+	return request
+}
+
+/*
+Takes back the last bid accepted. That is only possible within the retract
+window of the auction, after that only the auctioneer can
+*/
+func retract(c pb.AuctionClient, ctx context.Context) {
+	if len(lastBid) == 0 {
+		logger.EPrintf("You have no bid to retract\n")
+		return
+	}
+
+	reply, err := c.RetractBid(ctx, &pb.RetractRequest{
+		AuctionId: *auctionID,
+		Bidder:    *bidder,
+		RequestId: lastBid,
+	})
+	if err != nil {
+		logger.EPrintf("Failed to retract: %v\n", err)
+		return
+	} else if reply.Outcome != pb.RetractReply_SUCCESS {
+		logger.EPrintf("Retraction failed with outcome: %s\n", reply.Outcome)
+		return
+	}
+
+	logger.IPrintf("Retracted your bid\n")
+	lastBid = ""
+}
+
+// Shows the funds of the bidder and what is held for the auctions they lead
+func account(c pb.AuctionClient, ctx context.Context) {
+	reply, err := c.GetAccount(ctx, &pb.GetAccountRequest{
+		Bidder: *bidder,
+	})
+	if err != nil {
+		logger.EPrintf("Failed to retrieve your account: %v\n", err)
+		return
+	} else if reply.Outcome != pb.AccountReply_SUCCESS {
+		logger.EPrintf("Could not retrieve your account: %s\n", reply.Outcome)
+		return
+	}
+
+	info := reply.GetAccount()
+	logger.IPrintf("Balance %s, credit limit %s, %s available\n", money.String(info.GetBalance()), money.String(info.GetCreditLimit()), money.String(info.GetAvailable()))
+	for _, hold := range info.GetHolds() {
+		logger.IPrintf("%s held for %s\n", money.String(hold.GetAmount()), hold.GetAuctionId())
+	}
+}
+
+func newRequestID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := crand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+func result(c pb.AuctionClient, ctx context.Context) (*pb.ResultReply, error) {
 	logger.IPrintf("Retrieving result\n")
 
-	reply, err := c.GetResult(ctx, &pb.ResultRequest{})
+	reply, err := c.GetResult(ctx, &pb.ResultRequest{
+		AuctionId: *auctionID,
+	})
 	if err != nil {
 		logger.EPrintf("Failed to retrieve result: %v\n", err)
-		return 0, nil
+		return nil, err
+	}
+
+	if reply.ResultAmount != nil {
+		currency = reply.ResultAmount.Currency
+	}
+
+	if reply.AskingPriceAmount != nil {
+		logger.IPrintf("Retrieved result: asking price is %s, auction is %s\n", money.String(reply.AskingPriceAmount), reply.AuctionState)
+	} else if len(reply.Winner) == 0 {
+		logger.IPrintf("Retrieved result: no bids yet, auction is %s\n", reply.AuctionState)
+	} else {
+		logger.IPrintf("Retrieved result: %s by %s at %s, paying %s, auction is %s\n", money.String(reply.ResultAmount), reply.Winner, time.UnixMilli(reply.BidTime).Format(time.RFC3339), money.String(reply.PriceAmount), reply.AuctionState)
+		if !reply.ReserveMet {
+			logger.IPrintf("The reserve price has not been met\n")
+		}
+	}
+
+	if reply.EndTime > 0 {
+		logger.IPrintf("Auction ends at %s\n", time.UnixMilli(reply.EndTime).Format(time.RFC3339))
+	}
+
+	return reply, nil
+}
+
+/*
+Prints the events of the auction until it is settled or cancelled. The load
+balancer moves the stream to another replica if one dies, so the stream is
+only opened again if the load balancer itself went away
+*/
+func watch(c pb.AuctionClient, ctx context.Context) {
+	var last uint64
+	for attempt := 0; attempt <= *retries; attempt++ {
+		if attempt > 0 {
+			logger.IPrintf("Watching again after seq %d, attempt %d of %d\n", last, attempt, *retries)
+			time.Sleep(time.Duration(attempt) * 200 * time.Millisecond)
+		}
+
+		stream, err := c.WatchAuction(ctx, &pb.WatchRequest{
+			AuctionId: *auctionID,
+			AfterSeq:  last,
+		})
+		if err != nil {
+			logger.EPrintf("Failed to watch: %v\n", err)
+			if !retryable(err) {
+				return
+			}
+			continue
+		}
+
+		for {
+			event, err := stream.Recv()
+			if err == io.EOF {
+				logger.IPrintf("Auction is over\n")
+				return
+			} else if err != nil {
+				logger.EPrintf("Lost the event stream: %v\n", err)
+				if !retryable(err) {
+					return
+				}
+				break
+			}
+
+			last = event.Seq
+			attempt = 0
+			printEvent(event)
+		}
 	}
+}
+
+func printEvent(event *pb.AuctionEvent) {
+	reply := event.GetResult()
+	at := time.UnixMilli(event.Time).Format(time.RFC3339)
 
-	logger.IPrintf("Retrieved result: %d\n", reply.Result)
-	return reply.Result, nil
+	switch event.Kind {
+	case pb.AuctionEvent_NEW_HIGHEST_BID:
+		logger.IPrintf("[%d] %s: %s is the highest bidder with %s\n", event.Seq, at, reply.Winner, money.String(reply.ResultAmount))
+	case pb.AuctionEvent_EXTENDED:
+		logger.IPrintf("[%d] %s: auction was extended until %s\n", event.Seq, at, time.UnixMilli(reply.EndTime).Format(time.RFC3339))
+	case pb.AuctionEvent_STATE_CHANGED:
+		logger.IPrintf("[%d] %s: auction is %s\n", event.Seq, at, reply.AuctionState)
+	default:
+		logger.IPrintf("[%d] %s: auction is %s, highest bid %s by %q, ends at %s\n", event.Seq, at, reply.AuctionState, money.String(reply.ResultAmount), reply.Winner, time.UnixMilli(reply.EndTime).Format(time.RFC3339))
+	}
 }
 
 func outcomeToString(outcome pb.BidReply_Outcome) string {
@@ -138,6 +534,10 @@ func outcomeToString(outcome pb.BidReply_Outcome) string {
 		return "EXCEPTION"
 	case pb.BidReply_FAIL:
 		return "FAIL"
+	case pb.BidReply_UNAVAILABLE:
+		return "UNAVAILABLE"
+	case pb.BidReply_NOT_OPEN:
+		return "NOT_OPEN"
 	default:
 		return ""
 	}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	goTime "time"
+
+	"github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"github.com/ap/DMP3/internal/money"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Sends a change to an account to a single replica
+type accountCall func(client api.AuctionAdminClient, ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error)
+
+func (s *adminServer) OpenAccount(ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+	return s.runAccount(ctx, request, func(client api.AuctionAdminClient, ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+		return client.OpenAccount(ctx, request)
+	})
+}
+
+func (s *adminServer) Deposit(ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+	return s.runAccount(ctx, request, func(client api.AuctionAdminClient, ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+		return client.Deposit(ctx, request)
+	})
+}
+
+func (s *adminServer) Withdraw(ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+	return s.runAccount(ctx, request, func(client api.AuctionAdminClient, ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+		return client.Withdraw(ctx, request)
+	})
+}
+
+func (s *adminServer) SetCreditLimit(ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+	return s.runAccount(ctx, request, func(client api.AuctionAdminClient, ctx context.Context, request *api.AccountRequest) (*api.AccountReply, error) {
+		return client.SetCreditLimit(ctx, request)
+	})
+}
+
+/*
+Sends the change to the replica that can serve it, or to every live replica
+when they are coordinated by quorum. The time is fixed here, so every replica
+applies the change at the same time. The change succeeds once the write quorum
+took it and fails once a majority rejected it for the same reason
+*/
+func (s *adminServer) runAccount(ctx context.Context, request *api.AccountRequest, call accountCall) (*api.AccountReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	if len(request.Bidder) == 0 || request.Amount == nil {
+		return nil, status.Error(codes.InvalidArgument, "an account request needs a bidder and an amount")
+	}
+
+	request = proto.Clone(request).(*api.AccountRequest)
+	if request.Time <= 0 {
+		request.Time = goTime.Now().UnixMilli()
+	}
+	if len(request.Amount.Currency) == 0 {
+		request.Amount.Currency = money.DefaultCurrency
+	}
+
+	l := s.l
+	if l.replication != replicationQuorum {
+		var response *api.AccountReply
+		err := l.forward(func(endpoint string) (err error) {
+			response, err = s.SendAccount(endpoint, request, call)
+			return err
+		})
+		if err != nil {
+			return &api.AccountReply{
+				Outcome: api.AccountReply_UNAVAILABLE,
+			}, nil
+		}
+
+		return response, nil
+	}
+
+	outcomes := make(map[api.AccountReply_Outcome]int)
+	var replies []*api.AccountReply
+	for index, v := range l.liveReplicas() {
+		if len(v) == 0 {
+			continue
+		}
+
+		reply, err := s.SendAccount(v, request, call)
+		if code := status.Code(err); code == codes.Unavailable || code == codes.DeadlineExceeded {
+			defer l.declareReplicaDead(index)
+		}
+		if err != nil {
+			continue
+		}
+
+		outcomes[reply.Outcome]++
+		replies = append(replies, reply)
+	}
+
+	decided := api.AccountReply_UNAVAILABLE
+	if outcomes[api.AccountReply_SUCCESS] >= l.writeQuorum {
+		decided = api.AccountReply_SUCCESS
+	} else {
+		for outcome, count := range outcomes {
+			if outcome != api.AccountReply_SUCCESS && count >= l.majority() {
+				decided = outcome
+			}
+		}
+	}
+
+	if decided == api.AccountReply_UNAVAILABLE {
+		logger.EPrintf("No quorum for the account change of %s: %v\n", request.Bidder, outcomes)
+	}
+
+	response := &api.AccountReply{
+		Outcome: decided,
+	}
+	for _, reply := range replies {
+		if reply.Outcome == decided {
+			response.Account = reply.Account
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// Bidders can only see their own account
+func (l *LoadBalancer) GetAccount(ctx context.Context, request *api.GetAccountRequest) (*api.AccountReply, error) {
+	if err := auth.Authorize(ctx, request.Bidder); err != nil {
+		return nil, err
+	}
+
+	var response *api.AccountReply
+	err := l.forward(func(endpoint string) (err error) {
+		response, err = l.SendGetAccount(endpoint, request)
+		return err
+	})
+
+	return response, err
+}
+
+// Send an account change
+func (s *adminServer) SendAccount(endpoint string, request *api.AccountRequest, call accountCall) (*api.AccountReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionAdminClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := call(client, metadata.AppendToOutgoingContext(ctx, adminTokenKey, s.token), request)
+	if err != nil {
+		logger.EPrintf("Account change errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Send GetAccount message
+func (l *LoadBalancer) SendGetAccount(endpoint string, request *api.GetAccountRequest) (*api.AccountReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.GetAccount(ctx, request)
+	if err != nil {
+		logger.EPrintf("GetAccount errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	goTime "time"
+
+	"github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Metadata key the admin token is sent in, must match the replicas
+const adminTokenKey = "admin-token"
+
+/*
+Checks the admin token in the metadata of the call. Without a token of its
+own the load balancer accepts no admin calls at all
+*/
+func authorizeAdmin(ctx context.Context, token string) error {
+	if len(token) == 0 {
+		return status.Error(codes.PermissionDenied, "the admin service is disabled, start with --adminToken to enable it")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, given := range md.Get(adminTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "missing or wrong admin token")
+}
+
+// adminServer serves the AuctionAdmin service to holders of the admin token
+type adminServer struct {
+	l     *LoadBalancer
+	token string
+	api.UnimplementedAuctionAdminServer
+}
+
+// Sends an admin call to a single replica
+type adminCall func(client api.AuctionAdminClient, ctx context.Context) (*api.AdminReply, error)
+
+func (s *adminServer) PauseAuction(ctx context.Context, request *api.AdminRequest) (*api.AdminReply, error) {
+	request = stampAdminRequest(request)
+	return s.run(ctx, request.AuctionId, func(client api.AuctionAdminClient, ctx context.Context) (*api.AdminReply, error) {
+		return client.PauseAuction(ctx, request)
+	})
+}
+
+func (s *adminServer) ResumeAuction(ctx context.Context, request *api.AdminRequest) (*api.AdminReply, error) {
+	request = stampAdminRequest(request)
+	return s.run(ctx, request.AuctionId, func(client api.AuctionAdminClient, ctx context.Context) (*api.AdminReply, error) {
+		return client.ResumeAuction(ctx, request)
+	})
+}
+
+func (s *adminServer) CloseAuction(ctx context.Context, request *api.AdminRequest) (*api.AdminReply, error) {
+	request = stampAdminRequest(request)
+	return s.run(ctx, request.AuctionId, func(client api.AuctionAdminClient, ctx context.Context) (*api.AdminReply, error) {
+		return client.CloseAuction(ctx, request)
+	})
+}
+
+func (s *adminServer) CancelAuction(ctx context.Context, request *api.AdminRequest) (*api.AdminReply, error) {
+	request = stampAdminRequest(request)
+	return s.run(ctx, request.AuctionId, func(client api.AuctionAdminClient, ctx context.Context) (*api.AdminReply, error) {
+		return client.CancelAuction(ctx, request)
+	})
+}
+
+func (s *adminServer) ExtendAuction(ctx context.Context, request *api.ExtendRequest) (*api.AdminReply, error) {
+	request = proto.Clone(request).(*api.ExtendRequest)
+	if request.Time <= 0 {
+		request.Time = goTime.Now().UnixMilli()
+	}
+
+	return s.run(ctx, request.AuctionId, func(client api.AuctionAdminClient, ctx context.Context) (*api.AdminReply, error) {
+		return client.ExtendAuction(ctx, request)
+	})
+}
+
+// Auctioneers hold the admin token rather than a bidder token, so they read results here
+func (s *adminServer) GetResult(ctx context.Context, request *api.ResultRequest) (*api.ResultReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	return s.l.GetResult(ctx, request)
+}
+
+// Fixes the time the action takes effect, so every replica takes it at the same time
+func stampAdminRequest(request *api.AdminRequest) *api.AdminRequest {
+	request = proto.Clone(request).(*api.AdminRequest)
+	if request.Time <= 0 {
+		request.Time = goTime.Now().UnixMilli()
+	}
+
+	return request
+}
+
+/*
+Sends the admin call to the replica that can serve it, or to every live
+replica when they are coordinated by quorum. The action succeeds once the
+write quorum took it. The auction info kept for read repairs is updated with
+the new end time
+*/
+func (s *adminServer) run(ctx context.Context, auctionID string, call adminCall) (*api.AdminReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	l := s.l
+	var response *api.AdminReply
+	if l.replication != replicationQuorum {
+		err := l.forward(func(endpoint string) (err error) {
+			response, err = s.SendAdmin(endpoint, call)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		succeeded := 0
+		var lastErr error
+		for index, v := range l.liveReplicas() {
+			if len(v) == 0 {
+				continue
+			}
+
+			reply, err := s.SendAdmin(v, call)
+			if code := status.Code(err); code == codes.Unavailable || code == codes.DeadlineExceeded {
+				defer l.declareReplicaDead(index)
+			}
+			if err != nil {
+				lastErr = err
+				continue
+			}
+
+			if reply.Outcome == api.AdminReply_SUCCESS {
+				succeeded++
+			}
+			if response == nil || reply.Outcome == api.AdminReply_SUCCESS {
+				response = reply
+			}
+		}
+
+		if response == nil && lastErr != nil {
+			return nil, lastErr
+		} else if response == nil {
+			return nil, errNoReplicas
+		} else if response.Outcome == api.AdminReply_SUCCESS && succeeded < l.writeQuorum {
+			logger.EPrintf("Only %d of %d replicas took the admin action on %s\n", succeeded, l.writeQuorum, auctionIDOrDefault(auctionID))
+			return nil, status.Errorf(codes.Unavailable, "only %d of %d replicas took the action", succeeded, l.writeQuorum)
+		}
+	}
+
+	if response.Outcome == api.AdminReply_SUCCESS && response.Auction != nil {
+		l.updateAuctionInfo(response.Auction)
+	}
+
+	return response, nil
+}
+
+// Adopts the end time and soft close of the auction, the replicas decide on both
+func (l *LoadBalancer) updateAuctionInfo(auction *api.AuctionInfo) {
+	l.auctionsMutex.Lock()
+	defer l.auctionsMutex.Unlock()
+
+	info, ok := l.auctions[auction.AuctionId]
+	if !ok {
+		return
+	}
+
+	info = proto.Clone(info).(*api.AuctionInfo)
+	info.EndTime = auction.EndTime
+	info.SoftClose = auction.SoftClose
+	l.auctions[auction.AuctionId] = info
+}
+
+// Send an admin call, with the admin token of the load balancer
+func (s *adminServer) SendAdmin(endpoint string, call adminCall) (*api.AdminReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionAdminClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := call(client, metadata.AppendToOutgoingContext(ctx, adminTokenKey, s.token))
+	if err != nil {
+		logger.EPrintf("Admin call errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
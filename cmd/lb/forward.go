@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	goTime "time"
+
+	"github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ways the replicas can keep their state in sync, must match the replicas
+const (
+	// The load balancer sends every request to all replicas and decides by quorum
+	replicationQuorum = "quorum"
+	// The replicas run Raft among themselves, so any single replica can serve a request
+	replicationRaft = "raft"
+	// Only the primary replica serves requests, the load balancer has to find it
+	replicationPrimaryBackup = "primary-backup"
+)
+
+var (
+	errNoReplicas = errors.New("no replica could serve the request")
+	errNoPrimary  = status.Error(codes.Unavailable, "no replica knows of a primary")
+)
+
+/*
+Sends a request to the replica, or replicas, that can serve it when the
+replicas keep themselves in sync
+*/
+func (l *LoadBalancer) forward(send func(endpoint string) error) error {
+	if l.replication == replicationPrimaryBackup {
+		return l.sendToPrimary(send)
+	}
+
+	return l.tryReplicas(send)
+}
+
+/*
+Sends a ledger read to the replica, or replicas, that can serve it. Replicas
+coordinated by quorum each book their own ledger, in the order they settled
+the auctions, so their entries and hashes differ. Those reads go to the first
+live replica in the order the replicas were given, so readers see the same
+chain for as long as it is up
+*/
+func (l *LoadBalancer) forwardLedgerRead(send func(endpoint string) error) error {
+	if l.replication != replicationQuorum {
+		return l.forward(send)
+	}
+
+	for _, endpoint := range l.liveReplicas() {
+		if len(endpoint) == 0 {
+			continue
+		}
+
+		if err := send(endpoint); err != nil {
+			logger.EPrintf("Replica %s could not serve the ledger, trying the next: %v\n", endpoint, err)
+			continue
+		}
+
+		return nil
+	}
+
+	return errNoReplicas
+}
+
+/*
+Sends the request to one replica at a time, starting after the one used for
+the previous request, until one of them answers
+*/
+func (l *LoadBalancer) tryReplicas(send func(endpoint string) error) error {
+	endpoints := l.liveReplicas()
+
+	l.roundRobinMutex.Lock()
+	start := l.index
+	l.index = (l.index + 1) % len(endpoints)
+	l.roundRobinMutex.Unlock()
+
+	for i := range endpoints {
+		endpoint := endpoints[(start+i)%len(endpoints)]
+		if len(endpoint) == 0 {
+			continue
+		}
+
+		if err := send(endpoint); err != nil {
+			logger.EPrintf("Replica %s could not serve the request, trying the next: %v\n", endpoint, err)
+			continue
+		}
+
+		return nil
+	}
+
+	return errNoReplicas
+}
+
+/*
+Sends the request to the primary. If it cannot be reached or says it is no
+longer the primary, the replicas are asked who the primary is and the
+request is sent once more. Any other error is returned as is, since the
+primary may have applied the request
+*/
+func (l *LoadBalancer) sendToPrimary(send func(endpoint string) error) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		primary, err := l.primaryEndpoint(attempt > 0)
+		if err != nil {
+			return err
+		}
+
+		err = send(primary)
+		if err == nil {
+			return nil
+		}
+
+		if code := status.Code(err); code != codes.FailedPrecondition && code != codes.Unavailable {
+			return err
+		}
+		logger.EPrintf("Primary %s could not serve the request: %v\n", primary, err)
+	}
+
+	return errNoPrimary
+}
+
+/*
+Returns the address of the primary, asking the replicas unless it is already
+known and refresh is false. The primary of the newest view wins
+*/
+func (l *LoadBalancer) primaryEndpoint(refresh bool) (string, error) {
+	l.primaryMutex.Lock()
+	defer l.primaryMutex.Unlock()
+
+	if len(l.primary) > 0 && !refresh {
+		return l.primary, nil
+	}
+
+	l.primary = ""
+	var newest *api.PrimaryReply
+	for _, v := range l.liveReplicas() {
+		if len(v) == 0 {
+			continue
+		}
+
+		reply, err := l.SendGetPrimary(v)
+		if err != nil || len(reply.PrimaryId) == 0 {
+			continue
+		}
+
+		if newest == nil || reply.View > newest.View {
+			newest = reply
+		}
+	}
+
+	if newest == nil {
+		return "", errNoPrimary
+	}
+
+	logger.IPrintf("Primary is %s at %s in view %d\n", newest.PrimaryId, newest.Address, newest.View)
+	l.primary = newest.Address
+
+	return l.primary, nil
+}
+
+// Send GetPrimary message
+func (l *LoadBalancer) SendGetPrimary(endpoint string) (*api.PrimaryReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewReplicaClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.GetPrimary(ctx, &api.PrimaryRequest{})
+	if err != nil {
+		logger.EPrintf("GetPrimary errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -3,118 +3,329 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"net"
 	"strings"
 	"sync"
 	goTime "time"
 
 	"github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"github.com/ap/DMP3/internal/certs"
 	"github.com/ap/DMP3/internal/logging"
+	"github.com/ap/DMP3/internal/money"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Auction used by requests that do not name one, kept for clients predating multiple auctions
+	defaultAuctionID = "default"
 )
 
 var (
 	logger = logging.New()
+	// How the replicas are dialed, with TLS if the load balancer was given a certificate
+	transport = grpc.WithInsecure()
 )
 
 type LoadBalancer struct {
 	api.UnimplementedAuctionServer
-	startTime        goTime.Time
+	auctions         map[string]*api.AuctionInfo
+	auctionsMutex    sync.RWMutex
+	replicas         []string
 	replicaEndpoints []string
+	replicasMutex    sync.RWMutex
+	replication      string
+	writeQuorum      int
+	readQuorum       int
+	auctionDuration  goTime.Duration
+	sessionWindow    int
 	index            int
 	roundRobinMutex  sync.Mutex
+	primary          string
+	primaryMutex     sync.Mutex
+	// Sent with repairs, replicas serving plaintext take them only with the admin token
+	adminToken string
+	// Whether the replicas take repairs from this load balancer, they need its certificate or the admin token
+	repairs bool
 }
 
-
 func main() {
 	serverAddrStr := flag.String("serverAddr", "abe123", "Server to connect to")
+	replication := flag.String("replication", replicationQuorum, "How the replicas keep in sync: quorum, raft or primary-backup")
+	writeQuorum := flag.Int("writeQuorum", 0, "Number of replicas that must accept a bid, defaults to a majority")
+	readQuorum := flag.Int("readQuorum", 0, "Number of replicas that must answer a result request, defaults to a majority")
+	probeInterval := flag.Duration("probeInterval", 2*goTime.Second, "How often replicas declared dead are checked to see if they are back")
+	auctionDuration := flag.Duration("auctionDuration", goTime.Minute, "How long auctions created without a duration run")
+	adminToken := flag.String("adminToken", "", "Token auctioneers must send to use the admin service, which is disabled if empty. Passed on to the replicas, so they need the same one")
+	authSecret := flag.String("authSecret", "", "Secret bidder tokens are signed with, every call to the Auction service needs one and bids are only taken from the bidder it was issued to. Anyone can bid as anyone if empty")
+	sessionWindow := flag.Int("sessionWindow", defaultSessionWindow, "Number of bids a bidding session may have in flight before no more are read from it")
+	tlsCert := flag.String("tlsCert", "", "Certificate to serve and dial the replicas with, plaintext if empty. Reloaded when the file changes")
+	tlsKey := flag.String("tlsKey", "", "Key of --tlsCert")
+	tlsCA := flag.String("tlsCA", "", "CA that signs the certificates of the replicas, and of the clients with --tlsClientAuth")
+	tlsClientAuth := flag.Bool("tlsClientAuth", false, "Only let in clients presenting a client certificate signed by --tlsCA")
 	flag.Parse()
 
+	var serverOptions []grpc.ServerOption
+	if len(*tlsCert) > 0 || len(*tlsKey) > 0 || len(*tlsCA) > 0 {
+		if len(*tlsCert) == 0 || len(*tlsKey) == 0 || len(*tlsCA) == 0 {
+			logger.EPrintf("TLS needs --tlsCert, --tlsKey and --tlsCA\n")
+			return
+		}
+
+		reloader, err := certs.NewReloader(*tlsCert, *tlsKey, *tlsCA)
+		if err != nil {
+			logger.EPrintf("Failed to load the certificates: %v\n", err)
+			return
+		}
+
+		var roles []string
+		if *tlsClientAuth {
+			roles = []string{certs.RoleClient}
+		}
+		serverOptions = append(serverOptions, grpc.Creds(reloader.ServerCredentials(roles...)))
+		transport = grpc.WithTransportCredentials(reloader.ClientCredentials())
+		logger.IPrintf("Serving TLS, client certificates required: %t\n", *tlsClientAuth)
+	} else if *tlsClientAuth {
+		logger.EPrintf("--tlsClientAuth needs --tlsCert, --tlsKey and --tlsCA\n")
+		return
+	}
+
 	servernames := strings.Split(*serverAddrStr, ",")
 	logger.IPrintf("Replicas to forward reqeusts to: %v\n", servernames)
 
 	// Get list of replicas
 	s := &LoadBalancer{
-		replicaEndpoints: servernames,
+		auctions:         make(map[string]*api.AuctionInfo),
+		replicas:         servernames,
+		replicaEndpoints: append([]string{}, servernames...),
+		replication:      *replication,
+		writeQuorum:      *writeQuorum,
+		readQuorum:       *readQuorum,
+		auctionDuration:  *auctionDuration,
+		sessionWindow:    *sessionWindow,
 		index:            0,
-		roundRobinMutex: sync.Mutex{},
+		roundRobinMutex:  sync.Mutex{},
+		adminToken:       *adminToken,
+		repairs:          len(*tlsCert) > 0 || len(*adminToken) > 0,
+	}
+	if s.replication == replicationQuorum && !s.repairs {
+		logger.IPrintf("Without --tlsCert or --adminToken the replicas take no repairs, replicas that fall behind stay behind\n")
+	}
+
+	if s.replication != replicationQuorum && s.replication != replicationRaft && s.replication != replicationPrimaryBackup {
+		logger.EPrintf("Unknown replication %q\n", s.replication)
+		return
+	}
+
+	if s.writeQuorum <= 0 {
+		s.writeQuorum = s.majority()
+	}
+	if s.readQuorum <= 0 {
+		s.readQuorum = s.majority()
+	}
+	if s.sessionWindow <= 0 {
+		s.sessionWindow = defaultSessionWindow
 	}
-	s.StartServer()
+	logger.IPrintf("Using a write quorum of %d and a read quorum of %d out of %d replicas\n", s.writeQuorum, s.readQuorum, len(servernames))
+
+	go s.probeReplicas(*probeInterval)
+	s.StartServer(*adminToken, *authSecret, serverOptions...)
 }
 
+/*
+Forwards the bid to the replicas. Whether the auction is open is up to them,
+they own its lifecycle
+*/
 func (l *LoadBalancer) Bid(ctx context.Context, request *api.BidRequest) (*api.BidReply, error) {
+	if err := auth.Authorize(ctx, request.Bidder); err != nil {
+		return nil, err
+	}
 
-	if l.isAuctionLive() {
+	return l.placeBid(request, l.SendBid), nil
+}
 
-		for index, v := range l.replicaEndpoints {
-			if len(v) > 0 {
-				response, err := l.SendBid(v, request)
+/*
+Formats the amount of a bid for the log. Old clients only send the int32
+amount, which is in the currency of the auction
+*/
+func bidString(request *api.BidRequest) string {
+	amount, currency := money.OrLegacy(request.GetBidAmount(), request.GetBid())
+	if len(currency) == 0 {
+		return fmt.Sprintf("%d", amount)
+	}
 
-				if err != nil {
-					logger.EPrintf("failed to listen: %v", err)
-					defer l.declareReplicaDead(index)
-				} else if response.Outcome == api.BidReply_EXCEPTION {
-					defer l.declareReplicaDead(index)
-				}
-			}
-		}
+	return money.Format(amount, currency)
+}
 
+// Whether the bid comes with a maximum for proxy bidding
+func hasMaxBid(request *api.BidRequest) bool {
+	max, _ := money.OrLegacy(request.GetMaxBidAmount(), request.GetMaxBid())
+	return max > 0
+}
+
+// Sends a bid to a single replica
+type bidSender func(endpoint string, request *api.BidRequest) (*api.BidReply, error)
+
+func (l *LoadBalancer) placeBid(request *api.BidRequest, send bidSender) *api.BidReply {
+	request = stampBidRequest(request)
+
+	if l.replication == replicationQuorum && hasMaxBid(request) {
+		// Broadcast bids reach the replicas in different orders, so their proxies would disagree
 		return &api.BidReply{
-			// SUCCESS
-			Outcome: api.BidReply_SUCCESS,
-		}, nil
-	} else {
-		return &api.BidReply{
-			// FAIL
 			Outcome: api.BidReply_FAIL,
-		}, nil
+			Reason:  api.BidReply_PROXY_NOT_SUPPORTED,
+		}
+	}
+
+	if l.replication != replicationQuorum {
+
+		var response *api.BidReply
+		err := l.forward(func(endpoint string) (err error) {
+			response, err = send(endpoint, request)
+			return err
+		})
+		if err != nil {
+			return &api.BidReply{
+				Outcome: api.BidReply_UNAVAILABLE,
+			}
+		}
+
+		return response
+	}
+
+	results := l.broadcastBid(request, send)
+	for _, result := range results {
+		if result.err != nil {
+			logger.EPrintf("failed to listen: %v", result.err)
+			defer l.declareReplicaDead(result.index)
+		} else if result.reply.Outcome == api.BidReply_EXCEPTION {
+			defer l.declareReplicaDead(result.index)
+		}
 	}
+
+	return l.decideBid(request, results)
+}
+
+/*
+Fixes the time of the bid, so every replica prices a Dutch auction and checks
+the end time against the same time. A time set by the client is replaced, it
+would otherwise pick its own price
+*/
+func stampBidRequest(request *api.BidRequest) *api.BidRequest {
+	request = proto.Clone(request).(*api.BidRequest)
+	request.Time = goTime.Now().UnixMilli()
+
+	return request
 }
 
 // server
-func (l *LoadBalancer) StartServer() {
+func (l *LoadBalancer) StartServer(adminToken string, authSecret string, options ...grpc.ServerOption) {
 
-	l.startAuction()
 	lis, err := net.Listen("tcp", ":5000")
 	if err != nil {
 		logger.EPrintf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+
+	// The admin service has its own token, only the Auction service takes bidder tokens
+	if len(authSecret) > 0 {
+		logger.IPrintf("Bidders must authenticate with a token\n")
+		options = append(options,
+			grpc.UnaryInterceptor(auth.UnaryInterceptor([]byte(authSecret), "Auction")),
+			grpc.StreamInterceptor(auth.StreamInterceptor([]byte(authSecret), "Auction")))
+	}
+
+	admin := &adminServer{l: l, token: adminToken}
+	if l.replication == replicationQuorum {
+		if len(adminToken) > 0 {
+			go admin.createDefaultAuction(goTime.Second)
+		} else {
+			logger.EPrintf("The replicas only take auctions with the admin token, there is no default auction without --adminToken\n")
+		}
+	}
+
+	s := grpc.NewServer(options...)
 	api.RegisterAuctionServer(s, l)
+	api.RegisterAuctionAdminServer(s, admin)
 	logger.IPrintf("server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
 		logger.EPrintf("failed to serve: %v", err)
 	}
 }
 
-func (l *LoadBalancer) startAuction() {
-	l.startTime = goTime.Now()
-	logger.IPrintf("Starting auction at: %s\n", l.startTime)
+func (l *LoadBalancer) auctionInfo(auctionID string) (*api.AuctionInfo, bool) {
+	l.auctionsMutex.RLock()
+	defer l.auctionsMutex.RUnlock()
+
+	auction, ok := l.auctions[auctionIDOrDefault(auctionID)]
+	return auction, ok
 }
 
-func (l *LoadBalancer) isAuctionLive() bool {
-	elapsed := goTime.Since(l.startTime)
-	return elapsed.Minutes() < 1
+// Maps an empty auction id to the default auction
+func auctionIDOrDefault(id string) string {
+	if len(id) == 0 {
+		return defaultAuctionID
+	}
+
+	return id
 }
 
 func (l *LoadBalancer) declareReplicaDead(replicaEnpointIndex int) {
+	l.replicasMutex.Lock()
+	defer l.replicasMutex.Unlock()
+
+	if len(l.replicaEndpoints[replicaEnpointIndex]) > 0 {
+		logger.EPrintf("Declaring replica %s dead\n", l.replicaEndpoints[replicaEnpointIndex])
+	}
 	l.replicaEndpoints[replicaEnpointIndex] = ""
 }
 
-// Send Res message
-func (l *LoadBalancer) SendBid(endpoint string, request *api.BidRequest) (*api.BidReply, error) {
+// Copy of the replica endpoints, where the ones declared dead are empty
+func (l *LoadBalancer) liveReplicas() []string {
+	l.replicasMutex.RLock()
+	defer l.replicasMutex.RUnlock()
 
-	if !l.isAuctionLive() {
-		logger.IPrintf("Auction is finished! Denying bid request from %s", endpoint)
+	return append([]string{}, l.replicaEndpoints...)
+}
 
-		return &api.BidReply{
-			Outcome: api.BidReply_FAIL,
-		}, nil
+/*
+Puts replicas declared dead back into rotation once they answer again. A
+replica only starts serving after it caught up with its peers, so answering
+means it is safe to read from again
+*/
+func (l *LoadBalancer) probeReplicas(interval goTime.Duration) {
+	ticker := goTime.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for index, endpoint := range l.liveReplicas() {
+			if len(endpoint) > 0 {
+				continue
+			}
+
+			endpoint = l.replicas[index]
+			if _, err := l.SendStatus(endpoint); err != nil {
+				continue
+			}
+
+			logger.IPrintf("Replica %s is back, putting it into rotation\n", endpoint)
+			l.replicasMutex.Lock()
+			l.replicaEndpoints[index] = endpoint
+			l.replicasMutex.Unlock()
+		}
 	}
+}
+
+// Send Res message
+func (l *LoadBalancer) SendBid(endpoint string, request *api.BidRequest) (*api.BidReply, error) {
 
-	logger.IPrintf("Send bid %v to: %s\n", request.Bid, endpoint)
+	logger.IPrintf("Send bid %s from %s (request %s) to: %s\n", bidString(request), request.Bidder, request.RequestId, endpoint)
 
-	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	conn, err := grpc.Dial(endpoint, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -136,11 +347,11 @@ func (l *LoadBalancer) SendBid(endpoint string, request *api.BidRequest) (*api.B
 }
 
 // Send Res message
-func (l *LoadBalancer) SendGetResult(endpoint string) (*api.ResultReply, error) {
+func (l *LoadBalancer) SendGetResult(endpoint string, request *api.ResultRequest) (*api.ResultReply, error) {
 
 	logger.IPrintf("Send GetResult to: %s\n", endpoint)
 
-	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	conn, err := grpc.Dial(endpoint, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +363,7 @@ func (l *LoadBalancer) SendGetResult(endpoint string) (*api.ResultReply, error)
 	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
 	defer cancel()
 
-	response, err := client.GetResult(ctx, &api.ResultRequest{})
+	response, err := client.GetResult(ctx, request)
 	if err != nil {
 		logger.EPrintf("GetResult errored: %v\n", err)
 		return nil, err
@@ -162,19 +373,392 @@ func (l *LoadBalancer) SendGetResult(endpoint string) (*api.ResultReply, error)
 }
 
 /*
-Gets the result by using a round robin
+Gets the result from a read quorum of replicas and repairs the ones that are behind
+*/
+func (l *LoadBalancer) GetResult(_ context.Context, request *api.ResultRequest) (*api.ResultReply, error) {
+
+	if l.replication != replicationQuorum {
+		var response *api.ResultReply
+		err := l.forward(func(endpoint string) (err error) {
+			response, err = l.SendGetResult(endpoint, request)
+			return err
+		})
+
+		return response, err
+	}
+
+	results := l.broadcastGetResult(request)
+	for _, result := range results {
+		if result.err != nil {
+			defer l.declareReplicaDead(result.index)
+		}
+	}
+
+	highest, err := l.decideResult(request, results)
+	if err != nil {
+		return nil, err
+	}
+
+	l.readRepair(request, highest, results)
+
+	return highest, nil
+}
+
+/*
+Creates the auction on every live replica. The start and end times are
+decided here, so replicas coordinated by quorum open and close the auction at
+the same time. Only holders of the admin token may create auctions
 */
-func (l *LoadBalancer) GetResult(context.Context, *api.ResultRequest) (*api.ResultReply, error) {
+func (s *adminServer) CreateAuction(ctx context.Context, request *api.CreateAuctionRequest) (*api.CreateAuctionReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	return s.createAuction(request, false)
+}
+
+/*
+Creates the default auction the replicas coordinated by quorum do not create
+themselves, so they all get the same start and end time. It is tried again
+until the replicas created it, they may not be up yet
+*/
+func (s *adminServer) createDefaultAuction(interval goTime.Duration) {
+	now := goTime.Now()
+	request := &api.CreateAuctionRequest{
+		AuctionId: defaultAuctionID,
+		StartTime: now.UnixMilli(),
+		EndTime:   now.Add(s.l.auctionDuration).UnixMilli(),
+	}
+
+	for retried := false; ; retried = true {
+		reply, err := s.createAuction(request, retried)
+		if err != nil {
+			logger.EPrintf("Failed to create the default auction, trying again: %v\n", err)
+			goTime.Sleep(interval)
+			continue
+		}
+
+		if reply.Outcome == api.CreateAuctionReply_EXISTS {
+			logger.IPrintf("The default auction exists already\n")
+		} else if reply.Outcome != api.CreateAuctionReply_SUCCESS {
+			logger.EPrintf("Replicas did not create the default auction: %s\n", reply.Outcome)
+		}
+		return
+	}
+}
+
+/*
+Creates the auction on the replicas. When the request is retried, replicas
+that already created the auction on an earlier try count as creating it
+*/
+func (s *adminServer) createAuction(request *api.CreateAuctionRequest, retried bool) (*api.CreateAuctionReply, error) {
+	l := s.l
+
+	if len(request.AuctionId) == 0 {
+		return &api.CreateAuctionReply{
+			Outcome: api.CreateAuctionReply_EXCEPTION,
+		}, nil
+	}
+
+	l.auctionsMutex.Lock()
+	defer l.auctionsMutex.Unlock()
+
+	if _, ok := l.auctions[request.AuctionId]; ok {
+		return &api.CreateAuctionReply{
+			Outcome: api.CreateAuctionReply_EXISTS,
+		}, nil
+	}
+
+	startTime := goTime.Now()
+	if request.StartTime > 0 {
+		startTime = goTime.UnixMilli(request.StartTime)
+	}
+
+	duration := l.auctionDuration
+	if request.DurationSeconds > 0 {
+		duration = goTime.Duration(request.DurationSeconds) * goTime.Second
+	}
+
+	endTime := startTime.Add(duration)
+	if request.EndTime > 0 {
+		endTime = goTime.UnixMilli(request.EndTime)
+	}
+
+	currency := request.Currency
+	if len(currency) == 0 {
+		currency = money.DefaultCurrency
+	} else if !money.ValidCurrency(currency) {
+		return &api.CreateAuctionReply{
+			Outcome: api.CreateAuctionReply_EXCEPTION,
+		}, nil
+	}
 
-	l.roundRobinMutex.Lock()
-	defer l.roundRobinMutex.Unlock()
+	if !money.PricesInCurrency(request, currency) {
+		return &api.CreateAuctionReply{
+			Outcome: api.CreateAuctionReply_EXCEPTION,
+		}, nil
+	}
 
-	index := l.index
-	if l.index%3 == 0 {
-		l.index = 0
+	// In basis points, the replicas take their default fee if 0
+	if request.FeeBps < 0 || request.FeeBps > 10000 {
+		return &api.CreateAuctionReply{
+			Outcome: api.CreateAuctionReply_EXCEPTION,
+		}, nil
+	}
+
+	info := &api.AuctionInfo{
+		AuctionId:           request.AuctionId,
+		Item:                request.Item,
+		Type:                request.Type,
+		Dutch:               request.Dutch,
+		MinIncrement:        request.MinIncrement,
+		ReservePrice:        request.ReservePrice,
+		StartingPrice:       request.StartingPrice,
+		Increments:          request.Increments,
+		SoftClose:           request.SoftClose,
+		RetractWindowMs:     request.RetractWindowMs,
+		Currency:            currency,
+		Seller:              request.Seller,
+		FeeBps:              request.FeeBps,
+		StartTime:           startTime.UnixMilli(),
+		EndTime:             endTime.UnixMilli(),
+		MinIncrementAmount:  request.MinIncrementAmount,
+		ReservePriceAmount:  request.ReservePriceAmount,
+		StartingPriceAmount: request.StartingPriceAmount,
+	}
+
+	forward := &api.CreateAuctionRequest{
+		AuctionId:           info.AuctionId,
+		Item:                info.Item,
+		DurationSeconds:     request.DurationSeconds,
+		StartTime:           info.StartTime,
+		EndTime:             info.EndTime,
+		Type:                info.Type,
+		Dutch:               info.Dutch,
+		MinIncrement:        info.MinIncrement,
+		ReservePrice:        info.ReservePrice,
+		StartingPrice:       info.StartingPrice,
+		Increments:          info.Increments,
+		SoftClose:           info.SoftClose,
+		RetractWindowMs:     info.RetractWindowMs,
+		Currency:            info.Currency,
+		Seller:              info.Seller,
+		FeeBps:              info.FeeBps,
+		MinIncrementAmount:  info.MinIncrementAmount,
+		ReservePriceAmount:  info.ReservePriceAmount,
+		StartingPriceAmount: info.StartingPriceAmount,
+	}
+
+	if l.replication != replicationQuorum {
+		var response *api.CreateAuctionReply
+		err := l.forward(func(endpoint string) (err error) {
+			response, err = s.SendCreateAuction(endpoint, forward)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		} else if response.Outcome != api.CreateAuctionReply_SUCCESS && !(retried && response.Outcome == api.CreateAuctionReply_EXISTS) {
+			return response, nil
+		}
 	} else {
-		l.index += 1
+		outcomes := make(map[api.CreateAuctionReply_Outcome]int)
+		for index, v := range l.liveReplicas() {
+			if len(v) == 0 {
+				continue
+			}
+
+			reply, err := s.SendCreateAuction(v, forward)
+			if err != nil {
+				defer l.declareReplicaDead(index)
+				continue
+			}
+			if retried && reply.Outcome == api.CreateAuctionReply_EXISTS {
+				outcomes[api.CreateAuctionReply_SUCCESS]++
+			} else {
+				outcomes[reply.Outcome]++
+			}
+		}
+
+		if decided, ok := l.decideCreateAuction(outcomes); !ok {
+			logger.EPrintf("No quorum for creating auction %s: %v\n", info.AuctionId, outcomes)
+			return nil, status.Errorf(codes.Unavailable, "only %d of %d replicas created the auction", outcomes[api.CreateAuctionReply_SUCCESS], l.writeQuorum)
+		} else if decided != api.CreateAuctionReply_SUCCESS {
+			return &api.CreateAuctionReply{
+				Outcome: decided,
+			}, nil
+		}
+	}
+
+	logger.IPrintf("Created %s auction %s for %q from %s to %s\n", info.Type, info.AuctionId, info.Item, startTime, endTime)
+	l.auctions[info.AuctionId] = info
+
+	return &api.CreateAuctionReply{
+		Outcome: api.CreateAuctionReply_SUCCESS,
+	}, nil
+}
+
+/*
+Decides the outcome of creating an auction from the answers of the replicas.
+It is created once the write quorum created it and fails once a majority
+failed for the same reason. Anything else means the replicas could not agree
+*/
+func (l *LoadBalancer) decideCreateAuction(outcomes map[api.CreateAuctionReply_Outcome]int) (api.CreateAuctionReply_Outcome, bool) {
+	if outcomes[api.CreateAuctionReply_SUCCESS] >= l.writeQuorum {
+		return api.CreateAuctionReply_SUCCESS, true
 	}
 
-	return l.SendGetResult(l.replicaEndpoints[index])
+	for outcome, count := range outcomes {
+		if outcome != api.CreateAuctionReply_SUCCESS && count >= l.majority() {
+			return outcome, true
+		}
+	}
+
+	return api.CreateAuctionReply_EXCEPTION, false
+}
+
+/*
+Lists the auctions of a single replica, which knows the state each of them is in
+*/
+func (l *LoadBalancer) ListAuctions(_ context.Context, request *api.ListAuctionsRequest) (*api.ListAuctionsReply, error) {
+
+	var response *api.ListAuctionsReply
+	err := l.forward(func(endpoint string) (err error) {
+		response, err = l.SendListAuctions(endpoint, request)
+		return err
+	})
+
+	return response, err
+}
+
+/*
+Lists the bids of a single replica. With quorum replication the replicas may
+each have missed a few bids, but every acknowledged bid is on most of them
+*/
+func (l *LoadBalancer) ListBids(_ context.Context, request *api.ListBidsRequest) (*api.ListBidsReply, error) {
+
+	var response *api.ListBidsReply
+	err := l.forward(func(endpoint string) (err error) {
+		response, err = l.SendListBids(endpoint, request)
+		return err
+	})
+
+	return response, err
+}
+
+// Send ListBids message
+func (l *LoadBalancer) SendListBids(endpoint string, request *api.ListBidsRequest) (*api.ListBidsReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.ListBids(ctx, request)
+	if err != nil {
+		logger.EPrintf("ListBids errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Send ListAuctions message
+func (l *LoadBalancer) SendListAuctions(endpoint string, request *api.ListAuctionsRequest) (*api.ListAuctionsReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.ListAuctions(ctx, request)
+	if err != nil {
+		logger.EPrintf("ListAuctions errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Send Status message
+func (l *LoadBalancer) SendStatus(endpoint string) (*api.StatusReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewReplicaClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	return client.Status(ctx, &api.StatusRequest{})
+}
+
+// Send Repair message
+func (l *LoadBalancer) SendRepair(endpoint string, request *api.RepairRequest) (*api.RepairReply, error) {
+
+	logger.IPrintf("Send Repair %s to: %s\n", request.Auction.AuctionId, endpoint)
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewReplicaClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.Repair(metadata.AppendToOutgoingContext(ctx, adminTokenKey, l.adminToken), request)
+	if err != nil {
+		logger.EPrintf("Repair errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Send CreateAuction message, with the admin token of the load balancer
+func (s *adminServer) SendCreateAuction(endpoint string, request *api.CreateAuctionRequest) (*api.CreateAuctionReply, error) {
+
+	logger.IPrintf("Send CreateAuction %s to: %s\n", request.AuctionId, endpoint)
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionAdminClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.CreateAuction(metadata.AppendToOutgoingContext(ctx, adminTokenKey, s.token), request)
+	if err != nil {
+		logger.EPrintf("CreateAuction errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
 }
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Answer of a single replica to a forwarded bid, err is set if it could not be reached
+type replicaBidResult struct {
+	index    int
+	endpoint string
+	reply    *api.BidReply
+	err      error
+}
+
+// How far along its lifecycle each state is, a paused auction is no further than an open one
+var stateProgress = map[api.AuctionState]int{
+	api.AuctionState_SCHEDULED: 0,
+	api.AuctionState_OPEN:      1,
+	api.AuctionState_PAUSED:    1,
+	api.AuctionState_CLOSING:   2,
+	api.AuctionState_CLOSED:    3,
+	api.AuctionState_SETTLED:   4,
+	api.AuctionState_CANCELLED: 5,
+}
+
+// Number of replicas making up a majority of the configured replicas
+func (l *LoadBalancer) majority() int {
+	return len(l.replicas)/2 + 1
+}
+
+/*
+Sends the bid to every live replica at the same time and waits for all of them to answer
+*/
+func (l *LoadBalancer) broadcastBid(request *api.BidRequest, send bidSender) []replicaBidResult {
+	endpoints := l.liveReplicas()
+	results := make([]replicaBidResult, len(endpoints))
+	wg := sync.WaitGroup{}
+
+	for index, v := range endpoints {
+		results[index] = replicaBidResult{index: index, endpoint: v}
+		if len(v) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *replicaBidResult) {
+			defer wg.Done()
+			result.reply, result.err = send(result.endpoint, request)
+		}(&results[index])
+	}
+	wg.Wait()
+
+	live := results[:0]
+	for _, result := range results {
+		if len(result.endpoint) > 0 {
+			live = append(live, result)
+		}
+	}
+
+	return live
+}
+
+/*
+Decides the outcome of a bid from the answers of the replicas. The bid
+succeeds once the write quorum accepted it and fails once a majority rejected
+it, reporting the auction as not open if that is why a majority rejected it
+and the rule most of them rejected it by.
+Anything else means the replicas could not agree and the bid is reported as
+unavailable
+*/
+func (l *LoadBalancer) decideBid(request *api.BidRequest, results []replicaBidResult) *api.BidReply {
+	var accepted, rejected []string
+	notOpen := 0
+	reasons := make(map[api.BidReply_Reason]int)
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		switch result.reply.Outcome {
+		case api.BidReply_SUCCESS:
+			accepted = append(accepted, result.endpoint)
+		case api.BidReply_FAIL:
+			rejected = append(rejected, result.endpoint)
+			reasons[result.reply.Reason]++
+		case api.BidReply_NOT_OPEN:
+			rejected = append(rejected, result.endpoint)
+			reasons[result.reply.Reason]++
+			notOpen++
+		}
+	}
+
+	outcome := api.BidReply_UNAVAILABLE
+	if len(accepted) >= l.writeQuorum {
+		outcome = api.BidReply_SUCCESS
+	} else if notOpen >= l.majority() {
+		outcome = api.BidReply_NOT_OPEN
+	} else if len(rejected) >= l.majority() {
+		outcome = api.BidReply_FAIL
+	}
+
+	if len(accepted) > 0 && len(rejected) > 0 {
+		logger.EPrintf("Replicas disagree on bid %s by %s in %s, decided %s. Accepted: [%s], rejected: [%s]\n",
+			bidString(request), request.Bidder, auctionIDOrDefault(request.AuctionId), outcome,
+			strings.Join(accepted, ", "), strings.Join(rejected, ", "))
+	}
+
+	if outcome == api.BidReply_UNAVAILABLE {
+		logger.EPrintf("No quorum for bid %s by %s, %d of %d accepted, %d rejected\n",
+			bidString(request), request.Bidder, len(accepted), l.writeQuorum, len(rejected))
+	}
+
+	reply := &api.BidReply{
+		Outcome: outcome,
+	}
+
+	// The rule most replicas rejected the bid by
+	if outcome == api.BidReply_FAIL || outcome == api.BidReply_NOT_OPEN {
+		for reason, count := range reasons {
+			if count > reasons[reply.Reason] || (count == reasons[reply.Reason] && reason < reply.Reason) {
+				reply.Reason = reason
+			}
+		}
+	}
+
+	return reply
+}
+
+// Answer of a single replica to a forwarded result request, err is set if it could not be reached
+type replicaResult struct {
+	index    int
+	endpoint string
+	reply    *api.ResultReply
+	err      error
+}
+
+/*
+Asks every live replica for the result at the same time and waits for all of them to answer
+*/
+func (l *LoadBalancer) broadcastGetResult(request *api.ResultRequest) []replicaResult {
+	endpoints := l.liveReplicas()
+	results := make([]replicaResult, 0, len(endpoints))
+	for index, v := range endpoints {
+		if len(v) > 0 {
+			results = append(results, replicaResult{index: index, endpoint: v})
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range results {
+		wg.Add(1)
+		go func(result *replicaResult) {
+			defer wg.Done()
+			result.reply, result.err = l.SendGetResult(result.endpoint, request)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results
+}
+
+/*
+Picks the highest bid among the answers, failing if fewer than the read quorum
+answered. The auction is reported in the furthest state any replica reached,
+and with the latest end time any of them extended it to
+*/
+func (l *LoadBalancer) decideResult(request *api.ResultRequest, results []replicaResult) (*api.ResultReply, error) {
+	var highest *api.ResultReply
+	state := api.AuctionState_SCHEDULED
+	var endTime int64
+	answered := 0
+
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+
+		answered++
+		if stateProgress[result.reply.AuctionState] > stateProgress[state] {
+			state = result.reply.AuctionState
+		}
+		if result.reply.EndTime > endTime {
+			endTime = result.reply.EndTime
+		}
+		if highest == nil || isHigherResult(result.reply, highest) {
+			highest = result.reply
+		}
+	}
+
+	if answered < l.readQuorum {
+		logger.EPrintf("No quorum for result of %s, %d of %d answered\n", auctionIDOrDefault(request.AuctionId), answered, l.readQuorum)
+		return nil, status.Errorf(codes.Unavailable, "only %d of %d replicas answered", answered, l.readQuorum)
+	}
+
+	return &api.ResultReply{
+		Result:            highest.Result,
+		Winner:            highest.Winner,
+		BidTime:           highest.BidTime,
+		AuctionState:      state,
+		Price:             highest.Price,
+		AskingPrice:       highest.AskingPrice,
+		ReserveMet:        highest.ReserveMet,
+		EndTime:           endTime,
+		ResultAmount:      highest.ResultAmount,
+		PriceAmount:       highest.PriceAmount,
+		AskingPriceAmount: highest.AskingPriceAmount,
+	}, nil
+}
+
+/*
+Pushes the highest bid and the latest end time to every replica that answered
+with a lower bid or an earlier end time. The repairs are sent in the
+background so the reader does not wait for them. The replicas only create a
+missing auction if it was created through this load balancer, otherwise it
+does not know when the auction ends
+*/
+func (l *LoadBalancer) readRepair(request *api.ResultRequest, highest *api.ResultReply, results []replicaResult) {
+	if len(highest.Winner) == 0 || !l.repairs {
+		return
+	}
+
+	auction, ok := l.auctionInfo(request.AuctionId)
+	if ok {
+		auction = proto.Clone(auction).(*api.AuctionInfo)
+	} else {
+		auction = &api.AuctionInfo{
+			AuctionId: auctionIDOrDefault(request.AuctionId),
+		}
+	}
+	if highest.EndTime > auction.EndTime {
+		auction.EndTime = highest.EndTime
+	}
+
+	repair := &api.RepairRequest{
+		Auction: auction,
+		Result:  highest,
+	}
+
+	for _, result := range results {
+		if result.err != nil || (!isHigherResult(highest, result.reply) && result.reply.EndTime >= highest.EndTime) {
+			continue
+		}
+
+		logger.IPrintf("Replica %s is behind in %s with %d ending at %d, repairing to %d by %s ending at %d\n",
+			result.endpoint, auction.AuctionId, money.Result(result.reply), result.reply.EndTime, money.Result(highest), highest.Winner, highest.EndTime)
+		go l.SendRepair(result.endpoint, repair)
+	}
+}
+
+// Orders results by amount, an earlier bid wins a tie as it was accepted first
+func isHigherResult(a *api.ResultReply, b *api.ResultReply) bool {
+	if money.Result(a) != money.Result(b) {
+		return money.Result(a) > money.Result(b)
+	}
+
+	return len(a.Winner) > 0 && (len(b.Winner) == 0 || a.BidTime < b.BidTime)
+}
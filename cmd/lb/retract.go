@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	goTime "time"
+
+	"github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// Sends a retraction to a single replica
+type retractCall func(conn *grpc.ClientConn, ctx context.Context) (*api.RetractReply, error)
+
+func (l *LoadBalancer) RetractBid(ctx context.Context, request *api.RetractRequest) (*api.RetractReply, error) {
+	if err := auth.Authorize(ctx, request.Bidder); err != nil {
+		return nil, err
+	}
+
+	request = stampRetractRequest(request)
+	return l.retract(request, func(conn *grpc.ClientConn, ctx context.Context) (*api.RetractReply, error) {
+		return api.NewAuctionClient(conn).RetractBid(ctx, request)
+	}), nil
+}
+
+// Retracts a bid with the approval of the auctioneer, so the retract window does not apply
+func (s *adminServer) RetractBid(ctx context.Context, request *api.RetractRequest) (*api.RetractReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	request = stampRetractRequest(request)
+	return s.l.retract(request, func(conn *grpc.ClientConn, ctx context.Context) (*api.RetractReply, error) {
+		return api.NewAuctionAdminClient(conn).RetractBid(metadata.AppendToOutgoingContext(ctx, adminTokenKey, s.token), request)
+	}), nil
+}
+
+// Fixes the time of the retraction, so every replica checks the retract window against the same time
+func stampRetractRequest(request *api.RetractRequest) *api.RetractRequest {
+	request = proto.Clone(request).(*api.RetractRequest)
+	if request.Time <= 0 {
+		request.Time = goTime.Now().UnixMilli()
+	}
+
+	return request
+}
+
+/*
+Sends the retraction to the replica that can serve it. Replicas coordinated by
+quorum get bids in different orders, and a retraction restores whichever bid
+was highest before it, so they would disagree on the winner. Retractions are
+only taken with raft or primary-backup
+*/
+func (l *LoadBalancer) retract(request *api.RetractRequest, call retractCall) *api.RetractReply {
+	if l.replication == replicationQuorum {
+		logger.IPrintf("Retraction by %s in %s but the replicas do not order bids, rejecting\n", request.Bidder, auctionIDOrDefault(request.AuctionId))
+		return &api.RetractReply{
+			Outcome: api.RetractReply_NOT_SUPPORTED,
+		}
+	}
+
+	var response *api.RetractReply
+	err := l.forward(func(endpoint string) (err error) {
+		response, err = l.SendRetractBid(endpoint, call)
+		return err
+	})
+	if err != nil {
+		return &api.RetractReply{
+			Outcome: api.RetractReply_UNAVAILABLE,
+		}
+	}
+
+	return response
+}
+
+// Send RetractBid message
+func (l *LoadBalancer) SendRetractBid(endpoint string, call retractCall) (*api.RetractReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := call(conn, ctx)
+	if err != nil {
+		logger.EPrintf("RetractBid errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"io"
+	"sync"
+	goTime "time"
+
+	"github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/auth"
+	"github.com/ap/DMP3/internal/money"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of bids a session may have in flight before the load balancer stops reading more
+const defaultSessionWindow = 32
+
+// A bid read from a session, waiting for the bids before it in the same auction
+type queuedBid struct {
+	id      string
+	request *api.BidRequest
+}
+
+// The bid of a session's bidder that leads an auction
+type leadingBid struct {
+	bidder string
+	// In minor units, of the currency of the auction if the bidder did not say
+	bid      int64
+	currency string
+}
+
+/*
+session is a long-lived bidding stream. It keeps its connections to the
+replicas open for as long as it lasts, and watches every auction one of its
+bidders took the lead in, so it can tell them when they are outbid
+*/
+type session struct {
+	l      *LoadBalancer
+	ctx    context.Context
+	cancel context.CancelFunc
+	// Replies and notifications, sent by a single goroutine as a stream may not be sent on concurrently
+	out chan *api.SessionMessage
+	// Holds a token for every bid in flight
+	window chan struct{}
+	// Bids of every auction, placed one at a time in the order they were read
+	queues   map[string]chan *queuedBid
+	bids     sync.WaitGroup
+	watchers sync.WaitGroup
+	lock     sync.Mutex
+	inFlight map[string]bool
+	leaders  map[string]*leadingBid
+	watching map[string]bool
+	conns    map[string]*grpc.ClientConn
+}
+
+/*
+Runs a bidding session. Bids on different auctions are placed concurrently,
+bids on the same auction in the order they were sent, and their outcomes are
+sent as soon as they are known, carrying the correlation id of the bid. Once
+the window of bids in flight is full the load balancer stops reading, so the
+client is held back by the flow control of the stream itself.
+When the client closes its side, the bids in flight are still answered before
+the session ends. A client that goes away ends it right away
+*/
+func (l *LoadBalancer) BidSession(stream api.Auction_BidSessionServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	s := &session{
+		l:        l,
+		ctx:      ctx,
+		cancel:   cancel,
+		out:      make(chan *api.SessionMessage, l.sessionWindow),
+		window:   make(chan struct{}, l.sessionWindow),
+		queues:   make(map[string]chan *queuedBid),
+		inFlight: make(map[string]bool),
+		leaders:  make(map[string]*leadingBid),
+		watching: make(map[string]bool),
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+	defer s.closeConns()
+
+	logger.IPrintf("Opened a bidding session\n")
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- s.sendLoop(stream)
+	}()
+
+	err := s.receive(stream)
+	if err != nil {
+		cancel()
+	}
+
+	for _, queue := range s.queues {
+		close(queue)
+	}
+	s.bids.Wait()
+	cancel()
+	s.watchers.Wait()
+	close(s.out)
+
+	if sendErr := <-sent; err == nil {
+		err = sendErr
+	}
+
+	if err != nil {
+		logger.EPrintf("Bidding session ended: %v\n", err)
+	} else {
+		logger.IPrintf("Closed a bidding session\n")
+	}
+
+	return err
+}
+
+func (s *session) receive(stream api.Auction_BidSessionServer) error {
+	for {
+		select {
+		case s.window <- struct{}{}:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+
+		request, err := stream.Recv()
+		if err == io.EOF {
+			<-s.window
+			return nil
+		} else if err != nil {
+			<-s.window
+			return err
+		}
+
+		id := request.GetCorrelationId()
+		if len(id) == 0 || request.GetBid() == nil {
+			return status.Error(codes.InvalidArgument, "every bid on a session needs a correlation id")
+		}
+		if err := auth.Authorize(stream.Context(), request.GetBid().GetBidder()); err != nil {
+			return err
+		}
+
+		s.lock.Lock()
+		duplicate := s.inFlight[id]
+		s.inFlight[id] = true
+		s.lock.Unlock()
+
+		if duplicate {
+			return status.Errorf(codes.InvalidArgument, "correlation id %s is already in flight", id)
+		}
+
+		s.enqueue(&queuedBid{id: id, request: request.GetBid()})
+	}
+}
+
+/*
+Queues the bid behind the other bids on its auction. The window holds a
+token for every queued bid, so the queue always has room
+*/
+func (s *session) enqueue(bid *queuedBid) {
+	auctionID := auctionIDOrDefault(bid.request.GetAuctionId())
+
+	queue, ok := s.queues[auctionID]
+	if !ok {
+		queue = make(chan *queuedBid, cap(s.window))
+		s.queues[auctionID] = queue
+
+		s.bids.Add(1)
+		go s.placeBids(queue)
+	}
+
+	queue <- bid
+}
+
+func (s *session) placeBids(queue chan *queuedBid) {
+	defer s.bids.Done()
+
+	for bid := range queue {
+		if s.ctx.Err() == nil {
+			s.bid(bid.id, bid.request)
+		}
+		<-s.window
+	}
+}
+
+func (s *session) sendLoop(stream api.Auction_BidSessionServer) error {
+	for message := range s.out {
+		if err := stream.Send(message); err != nil {
+			s.cancel()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Queues a message for the client, dropped if the session is ending
+func (s *session) emit(message *api.SessionMessage) {
+	select {
+	case s.out <- message:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *session) bid(id string, request *api.BidRequest) {
+	reply := s.l.placeBid(request, s.sendBid)
+	if reply.Outcome == api.BidReply_SUCCESS {
+		s.lead(request)
+	}
+
+	s.lock.Lock()
+	delete(s.inFlight, id)
+	s.lock.Unlock()
+
+	s.emit(&api.SessionMessage{
+		CorrelationId: id,
+		Message: &api.SessionMessage_Reply{
+			Reply: reply,
+		},
+	})
+}
+
+// Remembers that the bidder leads the auction and watches it if the session is not already
+func (s *session) lead(request *api.BidRequest) {
+	auctionID := auctionIDOrDefault(request.GetAuctionId())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bid, currency := money.OrLegacy(request.GetBidAmount(), request.GetBid())
+	s.leaders[auctionID] = &leadingBid{
+		bidder:   request.GetBidder(),
+		bid:      bid,
+		currency: currency,
+	}
+
+	if s.watching[auctionID] {
+		return
+	}
+	s.watching[auctionID] = true
+
+	s.watchers.Add(1)
+	go s.watchAuction(auctionID)
+}
+
+func (s *session) watchAuction(auctionID string) {
+	defer s.watchers.Done()
+
+	err := s.l.watch(s.ctx, auctionID, 0, func(event *api.AuctionEvent) error {
+		s.checkOutbid(auctionID, event.GetResult())
+		return nil
+	})
+	if err != nil && s.ctx.Err() == nil {
+		logger.EPrintf("Session stopped watching %s: %v\n", auctionID, err)
+	}
+
+	s.lock.Lock()
+	delete(s.watching, auctionID)
+	s.lock.Unlock()
+}
+
+/*
+Notifies the client if someone else took the lead from its bidder. A result
+below the bid of the bidder comes from before it, from a replica that is
+behind, so it cannot have outbid them
+*/
+func (s *session) checkOutbid(auctionID string, result *api.ResultReply) {
+	s.lock.Lock()
+	leading, ok := s.leaders[auctionID]
+	if !ok || len(result.GetWinner()) == 0 || result.GetWinner() == leading.bidder || money.Result(result) < leading.bid {
+		s.lock.Unlock()
+		return
+	}
+	delete(s.leaders, auctionID)
+	s.lock.Unlock()
+
+	currency := leading.currency
+	if len(currency) == 0 {
+		currency = result.GetResultAmount().GetCurrency()
+	}
+
+	logger.IPrintf("%s was outbid in %s by %s with %s\n", leading.bidder, auctionID, result.GetWinner(), money.String(result.GetResultAmount()))
+	s.emit(&api.SessionMessage{
+		Message: &api.SessionMessage_Outbid{
+			Outbid: &api.OutbidNotice{
+				AuctionId: auctionID,
+				Bidder:    leading.bidder,
+				Bid:       money.Legacy(leading.bid),
+				Result:    result,
+				BidAmount: money.New(leading.bid, currency),
+			},
+		},
+	})
+}
+
+// Sends a bid over the connection the session keeps to the replica
+func (s *session) sendBid(endpoint string, request *api.BidRequest) (*api.BidReply, error) {
+
+	logger.IPrintf("Send bid %s from %s (request %s) to: %s\n", bidString(request), request.Bidder, request.RequestId, endpoint)
+
+	conn, err := s.conn(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// client
+	client := api.NewAuctionClient(conn)
+
+	// Not tied to the session, a bid sent to some replicas must reach the others too
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.Bid(ctx, request)
+	if err != nil {
+		logger.EPrintf("Bid errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *session) conn(endpoint string) (*grpc.ClientConn, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if conn, ok := s.conns[endpoint]; ok {
+		return conn, nil
+	}
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[endpoint] = conn
+
+	return conn, nil
+}
+
+func (s *session) closeConns() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for endpoint, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, endpoint)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	goTime "time"
+
+	"github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Shows what the buyer paid and the seller got, so only holders of the admin token may read it
+func (s *adminServer) GetSettlement(ctx context.Context, request *api.SettlementRequest) (*api.SettlementReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	var response *api.SettlementReply
+	err := s.l.forwardLedgerRead(func(endpoint string) (err error) {
+		response, err = s.SendGetSettlement(endpoint, request)
+		return err
+	})
+
+	return response, err
+}
+
+func (s *adminServer) ListLedgerEntries(ctx context.Context, request *api.ListLedgerEntriesRequest) (*api.ListLedgerEntriesReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	var response *api.ListLedgerEntriesReply
+	err := s.l.forwardLedgerRead(func(endpoint string) (err error) {
+		response, err = s.SendListLedgerEntries(endpoint, request)
+		return err
+	})
+
+	return response, err
+}
+
+// Send GetSettlement message, with the admin token of the load balancer
+func (s *adminServer) SendGetSettlement(endpoint string, request *api.SettlementRequest) (*api.SettlementReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionAdminClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.GetSettlement(metadata.AppendToOutgoingContext(ctx, adminTokenKey, s.token), request)
+	if err != nil {
+		logger.EPrintf("GetSettlement errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Send ListLedgerEntries message, with the admin token of the load balancer
+func (s *adminServer) SendListLedgerEntries(endpoint string, request *api.ListLedgerEntriesRequest) (*api.ListLedgerEntriesReply, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionAdminClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), goTime.Second)
+	defer cancel()
+
+	response, err := client.ListLedgerEntries(metadata.AppendToOutgoingContext(ctx, adminTokenKey, s.token), request)
+	if err != nil {
+		logger.EPrintf("ListLedgerEntries errored: %v\n", err)
+		return nil, err
+	}
+
+	return response, nil
+}
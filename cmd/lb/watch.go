@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+)
+
+func (l *LoadBalancer) WatchAuction(request *api.WatchRequest, stream api.Auction_WatchAuctionServer) error {
+	return l.watch(stream.Context(), request.GetAuctionId(), request.GetAfterSeq(), stream.Send)
+}
+
+/*
+Proxies the event stream of a single replica. With raft or primary-backup
+every replica applies the same commands, so if the replica dies the stream
+resumes on the next one from the last event sent. Replicas coordinated by
+quorum may each have applied other bids and number their events differently,
+so every stream starts with a snapshot of the auction instead, and the events
+are numbered on from the last one sent. It only gives up once every live
+replica failed in a row without sending anything
+*/
+func (l *LoadBalancer) watch(ctx context.Context, auctionID string, after uint64, send func(*api.AuctionEvent) error) error {
+	last := after
+	renumber := l.replication == replicationQuorum
+
+	forward := func(event *api.AuctionEvent) error {
+		if renumber {
+			event.Seq = last + 1
+		}
+
+		if err := send(event); err != nil {
+			return err
+		}
+
+		last = event.Seq
+		return nil
+	}
+
+	l.roundRobinMutex.Lock()
+	start := l.index
+	l.roundRobinMutex.Unlock()
+
+	var err error
+	failures := 0
+	for i := start; ; i++ {
+		endpoints := l.liveReplicas()
+		if failures >= len(endpoints) {
+			break
+		}
+
+		endpoint := endpoints[i%len(endpoints)]
+		if len(endpoint) == 0 {
+			failures++
+			continue
+		}
+
+		from := last
+		if renumber {
+			from = 0
+		}
+
+		var sent bool
+		sent, err = l.SendWatchAuction(ctx, endpoint, auctionID, from, forward)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			// The client went away, not the replica
+			return ctx.Err()
+		}
+
+		if sent {
+			failures = 0
+		}
+		failures++
+		logger.EPrintf("Lost the event stream of %s from %s after seq %d, trying the next replica: %v\n", auctionIDOrDefault(auctionID), endpoint, last, err)
+	}
+
+	if err == nil {
+		err = errNoReplicas
+	}
+
+	return err
+}
+
+/*
+Sends on the events of the replica after the sequence number. Reports whether
+any event was sent
+*/
+func (l *LoadBalancer) SendWatchAuction(ctx context.Context, endpoint string, auctionID string, after uint64, send func(*api.AuctionEvent) error) (bool, error) {
+
+	conn, err := grpc.Dial(endpoint, transport)
+	if err != nil {
+		return false, err
+	}
+
+	defer conn.Close()
+	// client
+	client := api.NewAuctionClient(conn)
+
+	events, err := client.WatchAuction(ctx, &api.WatchRequest{
+		AuctionId: auctionID,
+		AfterSeq:  after,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	sent := false
+	for {
+		event, err := events.Recv()
+		if err == io.EOF {
+			return sent, nil
+		} else if err != nil {
+			return sent, err
+		}
+
+		if err := send(event); err != nil {
+			return sent, err
+		}
+
+		sent = true
+	}
+}
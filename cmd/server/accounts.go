@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"sort"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+	"github.com/ap/DMP3/internal/raft"
+	"google.golang.org/grpc"
+)
+
+/*
+Account holds the funds of a bidder. While a bidder leads an auction, what
+they may have to pay is held, so they cannot bid it somewhere else too
+*/
+type Account struct {
+	Bidder      string
+	Currency    string
+	Balance     int64
+	CreditLimit int64 `json:",omitempty"`
+	// Funds held for the auctions the bidder leads, by auction
+	Holds map[string]int64 `json:",omitempty"`
+}
+
+func (a *Account) held() int64 {
+	var held int64
+	for _, amount := range a.Holds {
+		held += amount
+	}
+
+	return held
+}
+
+// What the bidder can still bid, their balance and credit minus what is held
+func (a *Account) available() int64 {
+	return a.Balance + a.CreditLimit - a.held()
+}
+
+func (a *Account) Info() *pb.Account {
+	auctionIDs := make([]string, 0, len(a.Holds))
+	for auctionID := range a.Holds {
+		auctionIDs = append(auctionIDs, auctionID)
+	}
+	sort.Strings(auctionIDs)
+
+	holds := make([]*pb.Hold, 0, len(auctionIDs))
+	for _, auctionID := range auctionIDs {
+		holds = append(holds, &pb.Hold{
+			AuctionId: auctionID,
+			Amount:    money.New(a.Holds[auctionID], a.Currency),
+		})
+	}
+
+	return &pb.Account{
+		Bidder:      a.Bidder,
+		Balance:     money.New(a.Balance, a.Currency),
+		CreditLimit: money.New(a.CreditLimit, a.Currency),
+		Held:        money.New(a.held(), a.Currency),
+		Available:   money.New(a.available(), a.Currency),
+		Holds:       holds,
+	}
+}
+
+// Creates a command changing the account of a bidder, at the time the load balancer chose if any
+func NewAccountCommand(op string, req *pb.AccountRequest) *Command {
+	cmd := NewAdminCommand(op, "", req.GetTime())
+	cmd.AuctionID = ""
+	cmd.Bidder = req.GetBidder()
+	cmd.Amount = req.GetAmount().GetMinorUnits()
+	cmd.Currency = req.GetAmount().GetCurrency()
+
+	return cmd
+}
+
+func (s *adminServer) OpenAccount(ctx context.Context, req *pb.AccountRequest) (*pb.AccountReply, error) {
+	return s.runAccount(ctx, NewAccountCommand(opOpenAccount, req), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AccountReply, error) {
+		return client.OpenAccount(ctx, req)
+	})
+}
+
+func (s *adminServer) Deposit(ctx context.Context, req *pb.AccountRequest) (*pb.AccountReply, error) {
+	return s.runAccount(ctx, NewAccountCommand(opDeposit, req), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AccountReply, error) {
+		return client.Deposit(ctx, req)
+	})
+}
+
+func (s *adminServer) Withdraw(ctx context.Context, req *pb.AccountRequest) (*pb.AccountReply, error) {
+	return s.runAccount(ctx, NewAccountCommand(opWithdraw, req), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AccountReply, error) {
+		return client.Withdraw(ctx, req)
+	})
+}
+
+func (s *adminServer) SetCreditLimit(ctx context.Context, req *pb.AccountRequest) (*pb.AccountReply, error) {
+	return s.runAccount(ctx, NewAccountCommand(opCreditLimit, req), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AccountReply, error) {
+		return client.SetCreditLimit(ctx, req)
+	})
+}
+
+/*
+Submits a change to an account, forwarding the call to the leader if this
+node is a Raft follower
+*/
+func (s *adminServer) runAccount(ctx context.Context, cmd *Command, forward func(pb.AuctionAdminClient, context.Context) (*pb.AccountReply, error)) (*pb.AccountReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	reply, err := s.n.submit(ctx, cmd)
+	if err == raft.ErrNotLeader {
+		var conn *grpc.ClientConn
+		conn, ctx, err = s.n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return forward(pb.NewAuctionAdminClient(conn), withAdminToken(ctx, s.token))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reply.(*pb.AccountReply), nil
+}
+
+func (n *Node) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.AccountReply, error) {
+
+	// Only the leader is guaranteed to have applied every acknowledged change
+	if n.raft != nil && n.raft.Role() != raft.Leader {
+		conn, ctx, err := n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return pb.NewAuctionClient(conn).GetAccount(ctx, req)
+	}
+	if err := n.readBarrier(ctx); err != nil {
+		return nil, err
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	account, ok := n.State.Accounts[req.GetBidder()]
+	if !ok {
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_UNKNOWN_ACCOUNT,
+		}, nil
+	}
+
+	return &pb.AccountReply{
+		Outcome: pb.AccountReply_SUCCESS,
+		Account: account.Info(),
+	}, nil
+}
+
+/*
+Opens an account or changes its funds. Funds held for bids can neither be
+withdrawn nor taken away by lowering the credit limit
+*/
+func (s *State) applyAccount(cmd *Command) *pb.AccountReply {
+	if len(cmd.Bidder) == 0 {
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_EXCEPTION,
+		}
+	}
+
+	// Snapshots written before accounts existed do not have any
+	if s.Accounts == nil {
+		s.Accounts = make(map[string]*Account)
+	}
+
+	account, ok := s.Accounts[cmd.Bidder]
+	if cmd.Op == opOpenAccount {
+		if ok {
+			return &pb.AccountReply{
+				Outcome: pb.AccountReply_EXISTS,
+				Account: account.Info(),
+			}
+		}
+		currency := currencyOrDefault(cmd.Currency)
+		if !money.ValidCurrency(currency) || cmd.Amount < 0 {
+			return &pb.AccountReply{
+				Outcome: pb.AccountReply_INVALID_AMOUNT,
+			}
+		}
+
+		logger.IPrintf("Opening an account for %s with %s\n", cmd.Bidder, money.Format(cmd.Amount, currency))
+		account = &Account{
+			Bidder:   cmd.Bidder,
+			Currency: currency,
+			Balance:  cmd.Amount,
+		}
+		s.Accounts[cmd.Bidder] = account
+
+		// The bidder may already lead auctions they bid in before having an account
+		for auctionID := range s.Auctions {
+			s.syncHolds(auctionID)
+		}
+
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_SUCCESS,
+			Account: account.Info(),
+		}
+	}
+
+	if !ok {
+		logger.IPrintf("%s of %s for unknown account %s, rejecting\n", cmd.Op, money.Format(cmd.Amount, cmd.Currency), cmd.Bidder)
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_UNKNOWN_ACCOUNT,
+		}
+	}
+
+	if cmd.Currency != account.Currency {
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_CURRENCY_MISMATCH,
+			Account: account.Info(),
+		}
+	}
+
+	if cmd.Amount < 0 || (cmd.Amount == 0 && cmd.Op != opCreditLimit) {
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_INVALID_AMOUNT,
+			Account: account.Info(),
+		}
+	}
+
+	balance, creditLimit := account.Balance, account.CreditLimit
+	switch cmd.Op {
+	case opDeposit:
+		balance += cmd.Amount
+	case opWithdraw:
+		balance -= cmd.Amount
+	case opCreditLimit:
+		creditLimit = cmd.Amount
+	}
+
+	// Credit can be bid with but not withdrawn
+	if balance < 0 && cmd.Op == opWithdraw {
+		logger.IPrintf("Withdrawal of %s is more than the balance of %s, rejecting\n", money.Format(cmd.Amount, cmd.Currency), cmd.Bidder)
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_INSUFFICIENT_FUNDS,
+			Account: account.Info(),
+		}
+	}
+
+	// Only changes taking funds away have to leave enough for what is held
+	if balance+creditLimit < account.Balance+account.CreditLimit && balance+creditLimit < account.held() {
+		logger.IPrintf("%s of %s would leave %s with less than is held, rejecting\n", cmd.Op, money.Format(cmd.Amount, cmd.Currency), cmd.Bidder)
+		return &pb.AccountReply{
+			Outcome: pb.AccountReply_INSUFFICIENT_FUNDS,
+			Account: account.Info(),
+		}
+	}
+
+	logger.IPrintf("Account %s: %s of %s\n", cmd.Bidder, cmd.Op, money.Format(cmd.Amount, cmd.Currency))
+	account.Balance = balance
+	account.CreditLimit = creditLimit
+
+	return &pb.AccountReply{
+		Outcome: pb.AccountReply_SUCCESS,
+		Account: account.Info(),
+	}
+}
+
+/*
+Rejects a bid the bidder cannot cover. The bid and the proxy maximum must
+fit in the available funds, counting what is already held for the bidder in
+the same auction, as the new hold replaces that one. Bidders without an
+account may only bid if the replicas do not require one. Returns nil if the
+bid is covered
+*/
+func (s *State) checkFunds(cmd *Command, auction *Auction) *pb.BidReply {
+	if auction.State != pb.AuctionState_OPEN {
+		// Rejected for that by the auction
+		return nil
+	}
+
+	account, ok := s.Accounts[cmd.Bidder]
+	if !ok {
+		if cmd.RequireAccount {
+			logger.IPrintf("%s has no account, rejecting their bid in %s\n", cmd.Bidder, auction.ID)
+			return rejectBid(pb.BidReply_FAIL, pb.BidReply_NO_ACCOUNT)
+		}
+		return nil
+	}
+
+	if account.Currency != auction.currency() {
+		logger.IPrintf("Account of %s is in %s, not %s like %s, rejecting\n", cmd.Bidder, account.Currency, auction.currency(), auction.ID)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_CURRENCY_MISMATCH)
+	}
+
+	required := cmd.Bid
+	if cmd.MaxBid > required {
+		required = cmd.MaxBid
+	}
+
+	if available := account.available() + account.Holds[auction.ID]; required > available {
+		logger.IPrintf("Bid of %s by %s in %s is more than their available %s, rejecting\n",
+			money.Format(required, account.Currency), cmd.Bidder, auction.ID, money.Format(available, account.Currency))
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_INSUFFICIENT_FUNDS)
+	}
+
+	return nil
+}
+
+/*
+Returns what has to be held for each bidder in the auction. The leader of an
+open auction may have to pay up to their proxy maximum, every sealed bid may
+turn out to be the winning one, and once the auction is closing the winner
+pays the price if the reserve was met. Nothing is held before the auction
+opens nor after it was settled or cancelled
+*/
+func (a *Auction) holds() map[string]int64 {
+	holds := make(map[string]int64)
+	switch a.State {
+	case pb.AuctionState_OPEN, pb.AuctionState_PAUSED:
+		if a.Type == pb.AuctionType_VICKREY {
+			for bidder, bid := range a.SealedBids {
+				holds[bidder] = bid.Amount
+			}
+		} else if len(a.HighestBidder) > 0 {
+			holds[a.HighestBidder] = a.HighestBid
+			if a.HighestMax > a.HighestBid {
+				holds[a.HighestBidder] = a.HighestMax
+			}
+		}
+	case pb.AuctionState_CLOSING, pb.AuctionState_CLOSED:
+		if a.reserveMet() {
+			holds[a.HighestBidder] = a.price()
+		}
+	}
+
+	return holds
+}
+
+// What the winner pays, the second highest bid in a sealed-bid auction
+func (a *Auction) price() int64 {
+	if a.Type == pb.AuctionType_VICKREY {
+		return a.Price
+	}
+
+	return a.HighestBid
+}
+
+/*
+Brings the holds of every account in line with the auction, after every
+command that may have changed who leads it. Bidders without an account have
+nothing to hold
+*/
+func (s *State) syncHolds(auctionID string) {
+	auction, ok := s.Auctions[auctionID]
+	if !ok || len(s.Accounts) == 0 {
+		return
+	}
+
+	holds := auction.holds()
+	for bidder, account := range s.Accounts {
+		amount, ok := holds[bidder]
+		if !ok || account.Currency != auction.currency() {
+			if _, held := account.Holds[auctionID]; held {
+				logger.IPrintf("Releasing the funds of %s held for %s\n", bidder, auctionID)
+				delete(account.Holds, auctionID)
+			}
+			continue
+		}
+
+		if account.Holds[auctionID] == amount {
+			continue
+		}
+		if account.Holds == nil {
+			account.Holds = make(map[string]int64)
+		}
+
+		logger.IPrintf("Holding %s of %s for %s\n", money.Format(amount, account.Currency), bidder, auctionID)
+		account.Holds[auctionID] = amount
+	}
+}
+
+/*
+Takes the price from the winner's account once the auction is settled, the
+hold is released by the next sync as nothing is held after settling
+*/
+func (s *State) chargeWinner(auction *Auction) {
+	if !auction.reserveMet() {
+		return
+	}
+
+	account, ok := s.Accounts[auction.HighestBidder]
+	if !ok || account.Currency != auction.currency() {
+		return
+	}
+
+	logger.IPrintf("Charging %s %s for %s\n", auction.HighestBidder, money.Format(auction.price(), account.Currency), auction.ID)
+	account.Balance -= auction.price()
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+	"github.com/ap/DMP3/internal/raft"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata key the admin token is sent in
+const adminTokenKey = "admin-token"
+
+var errNotPaused = errors.New("auction is not paused")
+
+/*
+Checks the admin token in the metadata of the call. Without a token of its
+own the node accepts no admin calls at all
+*/
+func authorizeAdmin(ctx context.Context, token string) error {
+	if len(token) == 0 {
+		return status.Error(codes.PermissionDenied, "the admin service is disabled, start with --adminToken to enable it")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, given := range md.Get(adminTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "missing or wrong admin token")
+}
+
+// Passes the admin token on when an admin call is forwarded to the leader
+func withAdminToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, adminTokenKey, token)
+}
+
+// adminServer serves the AuctionAdmin service of a node to holders of the admin token
+type adminServer struct {
+	n     *Node
+	token string
+	pb.UnimplementedAuctionAdminServer
+}
+
+func (s *adminServer) CreateAuction(ctx context.Context, req *pb.CreateAuctionRequest) (*pb.CreateAuctionReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	if !money.PricesInCurrency(req, currencyOrDefault(req.GetCurrency())) {
+		logger.IPrintf("Create auction request for %s has prices in another currency than the auction, rejecting\n", req.GetAuctionId())
+		return &pb.CreateAuctionReply{
+			Outcome: pb.CreateAuctionReply_EXCEPTION,
+		}, nil
+	}
+
+	reply, err := s.n.submit(ctx, NewCreateAuctionCommand(req, s.n.auctionDuration, s.n.feeBps))
+	if err == raft.ErrNotLeader {
+		conn, ctx, err := s.n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return pb.NewAuctionAdminClient(conn).CreateAuction(withAdminToken(ctx, s.token), req)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reply.(*pb.CreateAuctionReply), nil
+}
+
+func (s *adminServer) PauseAuction(ctx context.Context, req *pb.AdminRequest) (*pb.AdminReply, error) {
+	return s.run(ctx, NewAdminCommand(opPause, req.GetAuctionId(), req.GetTime()), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AdminReply, error) {
+		return client.PauseAuction(ctx, req)
+	})
+}
+
+func (s *adminServer) ResumeAuction(ctx context.Context, req *pb.AdminRequest) (*pb.AdminReply, error) {
+	return s.run(ctx, NewAdminCommand(opResume, req.GetAuctionId(), req.GetTime()), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AdminReply, error) {
+		return client.ResumeAuction(ctx, req)
+	})
+}
+
+func (s *adminServer) CloseAuction(ctx context.Context, req *pb.AdminRequest) (*pb.AdminReply, error) {
+	return s.run(ctx, NewAdminCommand(opClose, req.GetAuctionId(), req.GetTime()), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AdminReply, error) {
+		return client.CloseAuction(ctx, req)
+	})
+}
+
+func (s *adminServer) CancelAuction(ctx context.Context, req *pb.AdminRequest) (*pb.AdminReply, error) {
+	return s.run(ctx, NewAdminCommand(opCancel, req.GetAuctionId(), req.GetTime()), func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AdminReply, error) {
+		return client.CancelAuction(ctx, req)
+	})
+}
+
+func (s *adminServer) ExtendAuction(ctx context.Context, req *pb.ExtendRequest) (*pb.AdminReply, error) {
+	cmd := NewAdminCommand(opExtend, req.GetAuctionId(), req.GetTime())
+	cmd.EndTime = req.GetEndTime()
+
+	return s.run(ctx, cmd, func(client pb.AuctionAdminClient, ctx context.Context) (*pb.AdminReply, error) {
+		return client.ExtendAuction(ctx, req)
+	})
+}
+
+// Auctioneers hold the admin token rather than a bidder token, so they read results here
+func (s *adminServer) GetResult(ctx context.Context, req *pb.ResultRequest) (*pb.ResultReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	return s.n.GetResult(ctx, req)
+}
+
+/*
+Submits an admin command, forwarding the call to the leader if this node is
+a Raft follower
+*/
+func (s *adminServer) run(ctx context.Context, cmd *Command, forward func(pb.AuctionAdminClient, context.Context) (*pb.AdminReply, error)) (*pb.AdminReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	reply, err := s.n.submit(ctx, cmd)
+	if err == raft.ErrNotLeader {
+		var conn *grpc.ClientConn
+		conn, ctx, err = s.n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return forward(pb.NewAuctionAdminClient(conn), withAdminToken(ctx, s.token))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reply.(*pb.AdminReply), nil
+}
+
+/*
+Creates an admin command. The time the load balancer chose is used if there
+is one, so every replica it sends the command to takes the action at the
+same time
+*/
+func NewAdminCommand(op string, auctionID string, at int64) *Command {
+	now := time.Now()
+	if at > 0 {
+		now = time.UnixMilli(at)
+	}
+
+	return &Command{
+		Op:        op,
+		Time:      now.UnixNano(),
+		AuctionID: auctionIDOrDefault(auctionID),
+	}
+}
+
+/*
+Takes the action of an admin command. Time driven transitions were already
+made, so an auction whose end time passed is closing and can no longer be
+paused or extended
+*/
+func (s *State) applyAdmin(cmd *Command) *pb.AdminReply {
+	auction, ok := s.Auctions[cmd.AuctionID]
+	if !ok {
+		logger.IPrintf("Admin %s of unknown auction %s, rejecting\n", cmd.Op, cmd.AuctionID)
+		return &pb.AdminReply{
+			Outcome: pb.AdminReply_UNKNOWN_AUCTION,
+		}
+	}
+
+	at := time.Unix(0, cmd.Time)
+
+	var err error
+	switch cmd.Op {
+	case opPause:
+		err = auction.transition(pb.AuctionState_PAUSED, at)
+	case opResume:
+		err = auction.resume(at)
+	case opClose:
+		err = auction.close(at)
+	case opCancel:
+		err = auction.transition(pb.AuctionState_CANCELLED, at)
+	case opExtend:
+		err = auction.extendTo(timeOrZero(cmd.EndTime))
+	}
+
+	if err != nil {
+		logger.IPrintf("Rejecting %s of %s: %v\n", cmd.Op, cmd.AuctionID, err)
+		return &pb.AdminReply{
+			Outcome: pb.AdminReply_INVALID_STATE,
+			Auction: auction.Info(),
+		}
+	}
+
+	return &pb.AdminReply{
+		Outcome: pb.AdminReply_SUCCESS,
+		Auction: auction.Info(),
+	}
+}
+
+/*
+Opens a paused auction again. The end time, and the hard close, move by how
+long the auction was paused, so bidders get the time they were promised
+*/
+func (a *Auction) resume(at time.Time) error {
+	if a.State != pb.AuctionState_PAUSED {
+		return errNotPaused
+	}
+
+	paused := at.Sub(a.StateChanged)
+	if err := a.transition(pb.AuctionState_OPEN, at); err != nil {
+		return err
+	}
+
+	a.Paused += paused
+	if !a.EndTime.IsZero() {
+		a.EndTime = a.EndTime.Add(paused)
+	}
+	if a.SoftClose != nil && !a.SoftClose.HardClose.IsZero() {
+		a.moveHardClose(a.SoftClose.HardClose.Add(paused))
+	}
+
+	return nil
+}
+
+// Ends the auction right away, it is closing from now on like when it reaches its end time
+func (a *Auction) close(at time.Time) error {
+	if err := a.transition(pb.AuctionState_CLOSING, at); err != nil {
+		return err
+	}
+
+	a.EndTime = at
+
+	return nil
+}
+
+/*
+Moves the end time of an auction that has not ended yet. The auctioneer may
+go past the hard close of a soft close, which moves along
+*/
+func (a *Auction) extendTo(endTime time.Time) error {
+	if a.State != pb.AuctionState_SCHEDULED && a.State != pb.AuctionState_OPEN && a.State != pb.AuctionState_PAUSED {
+		return errInvalidTransition
+	}
+
+	if !endTime.After(a.EndTime) || !endTime.After(a.StartTime) {
+		return errors.New("the new end time must be later")
+	}
+
+	logger.IPrintf("Extending %s from %s to %s by the auctioneer\n", a.ID, a.EndTime, endTime)
+	a.EndTime = endTime
+	if a.SoftClose != nil && !a.SoftClose.HardClose.IsZero() && a.SoftClose.HardClose.Before(endTime) {
+		a.moveHardClose(endTime)
+	}
+
+	return nil
+}
+
+// Copies the soft close before changing it, the command that created the auction holds the same one
+func (a *Auction) moveHardClose(hardClose time.Time) {
+	softClose := *a.SoftClose
+	softClose.HardClose = hardClose
+	a.SoftClose = &softClose
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+)
+
+const (
+	// Auction used by requests that do not name one, kept for clients predating multiple auctions
+	defaultAuctionID = "default"
+)
+
+// Auction holds the state of a single auction hosted by the node
+type Auction struct {
+	ID   string
+	Item string
+	Type pb.AuctionType
+	// ISO 4217 code of the currency of every bid and price, the default currency if empty
+	Currency string `json:",omitempty"`
+	// Zero if the auction opened when it was created
+	StartTime time.Time
+	// Zero if the auction never ends
+	EndTime        time.Time
+	State          pb.AuctionState
+	StateChanged   time.Time
+	HighestBid     int64
+	HighestBidder  string
+	HighestBidTime time.Time
+	// Secret proxy maximum of the highest bidder in an English auction
+	HighestMax   int64 `json:",omitempty"`
+	MinIncrement int64 `json:",omitempty"`
+	// Hidden, the item is only sold if the highest bid reaches it
+	ReservePrice  int64           `json:",omitempty"`
+	StartingPrice int64           `json:",omitempty"`
+	Increments    []IncrementTier `json:",omitempty"`
+	SoftClose     *SoftClose      `json:",omitempty"`
+	// Number of times a late bid moved the end time
+	Extensions int `json:",omitempty"`
+	// How long after placing a bid a bidder may retract it, not at all if zero
+	RetractWindow time.Duration `json:",omitempty"`
+	// Who is paid when the item is sold, the house if empty
+	Seller string `json:",omitempty"`
+	// Platform fee taken from the price, in basis points
+	FeeBps int64 `json:",omitempty"`
+	// Every bid placed, in the order they were applied
+	Bids []*BidRecord `json:",omitempty"`
+	// Sequence number of the last event sent to watchers
+	EventSeq uint64 `json:",omitempty"`
+	// How long the auction has been paused in total, the Dutch price clock stops while it is
+	Paused time.Duration `json:",omitempty"`
+	// Best bid of every bidder in a sealed-bid auction, the highest one is only revealed once it closes
+	SealedBids map[string]*SealedBid `json:",omitempty"`
+	// What the winner of a sealed-bid auction pays
+	Price int64 `json:",omitempty"`
+	// How the asking price of a Dutch auction drops
+	Dutch *DutchSchedule `json:",omitempty"`
+}
+
+func NewAuction(id string, item string, auctionType pb.AuctionType, dutch *DutchSchedule, startTime time.Time, endTime time.Time) *Auction {
+	auction := &Auction{
+		ID:        id,
+		Item:      item,
+		Type:      auctionType,
+		Dutch:     dutch,
+		StartTime: startTime,
+		EndTime:   endTime,
+		State:     pb.AuctionState_SCHEDULED,
+	}
+
+	if auctionType == pb.AuctionType_VICKREY {
+		auction.SealedBids = make(map[string]*SealedBid)
+	}
+
+	return auction
+}
+
+func (a *Auction) Info() *pb.AuctionInfo {
+	return &pb.AuctionInfo{
+		AuctionId:           a.ID,
+		Item:                a.Item,
+		EndTime:             unixMilliOrZero(a.EndTime),
+		StartTime:           unixMilliOrZero(a.StartTime),
+		State:               a.State,
+		Type:                a.Type,
+		Dutch:               a.Dutch.Info(a.currency()),
+		MinIncrement:        money.Legacy(a.MinIncrement),
+		StartingPrice:       money.Legacy(a.StartingPrice),
+		Increments:          incrementTiersInfo(a.Increments, a.currency()),
+		SoftClose:           a.SoftClose.Info(),
+		RetractWindowMs:     a.RetractWindow.Milliseconds(),
+		Currency:            a.currency(),
+		Seller:              a.seller(),
+		FeeBps:              int32(a.FeeBps),
+		MinIncrementAmount:  money.New(a.MinIncrement, a.currency()),
+		StartingPriceAmount: money.New(a.StartingPrice, a.currency()),
+	}
+}
+
+// Auctions created before they had a currency are in the default currency
+func (a *Auction) currency() string {
+	if len(a.Currency) == 0 {
+		return money.DefaultCurrency
+	}
+
+	return a.Currency
+}
+
+/*
+Returns the result as it may be shown to the bidders at the given time. The
+bids in a sealed-bid auction are hidden until it closes, so only its state is
+shown
+*/
+func (a *Auction) Result(now time.Time) *pb.ResultReply {
+	if a.sealed() {
+		return &pb.ResultReply{
+			AuctionState: a.State,
+			EndTime:      unixMilliOrZero(a.EndTime),
+			ResultAmount: money.New(0, a.currency()),
+		}
+	}
+
+	price := a.HighestBid
+	if a.Type == pb.AuctionType_VICKREY {
+		price = a.Price
+	}
+
+	var askingPrice int64
+	if a.Type == pb.AuctionType_DUTCH && a.State == pb.AuctionState_OPEN {
+		askingPrice = a.askingPrice(now)
+	}
+
+	reply := &pb.ResultReply{
+		Result:       money.Legacy(a.HighestBid),
+		Winner:       a.HighestBidder,
+		BidTime:      unixMilliOrZero(a.HighestBidTime),
+		AuctionState: a.State,
+		Price:        money.Legacy(price),
+		AskingPrice:  money.Legacy(askingPrice),
+		ReserveMet:   a.reserveMet(),
+		EndTime:      unixMilliOrZero(a.EndTime),
+		ResultAmount: money.New(a.HighestBid, a.currency()),
+		PriceAmount:  money.New(price, a.currency()),
+	}
+	if askingPrice > 0 {
+		reply.AskingPriceAmount = money.New(askingPrice, a.currency())
+	}
+
+	return reply
+}
+
+// Places a bid according to the type of the auction
+func (a *Auction) placeBid(cmd *Command) *pb.BidReply {
+	if a.State != pb.AuctionState_OPEN {
+		logger.IPrintf("Bid request for %s while it is %s, rejecting\n", a.ID, a.State)
+		return rejectBid(pb.BidReply_NOT_OPEN, pb.BidReply_AUCTION_NOT_OPEN)
+	}
+
+	logger.IPrintf("Retrieved bid request: %d by %s, highest bid in %s at this moment: %d\n", cmd.Bid, cmd.Bidder, a.ID, a.HighestBid)
+
+	if len(cmd.Bidder) == 0 {
+		logger.IPrintf("Bid request is missing a bidder, rejecting\n")
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_MISSING_BIDDER)
+	}
+
+	// Bids of old clients have no currency, they bid in whatever the auction uses
+	if (len(cmd.Currency) > 0 && cmd.Currency != a.currency()) || (len(cmd.MaxCurrency) > 0 && cmd.MaxCurrency != a.currency()) {
+		logger.IPrintf("Bid in %s by %s is not in %s, the currency of %s, rejecting\n", cmd.Currency, cmd.Bidder, a.currency(), a.ID)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_CURRENCY_MISMATCH)
+	}
+
+	var reply *pb.BidReply
+	if a.Type == pb.AuctionType_VICKREY {
+		reply = a.placeSealedBid(cmd)
+	} else if a.Type == pb.AuctionType_DUTCH {
+		reply = a.acceptAskingPrice(cmd)
+	} else {
+		reply = a.placeProxyBid(cmd)
+	}
+
+	if reply.Outcome == pb.BidReply_SUCCESS && a.State == pb.AuctionState_OPEN {
+		a.extend(time.Unix(0, cmd.Time))
+	}
+
+	return reply
+}
+
+// Converts a time to Unix milliseconds, keeping the zero time at 0
+func unixMilliOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixMilli()
+}
+
+// Converts Unix milliseconds to a time, keeping 0 as the zero time
+func timeOrZero(unixMilli int64) time.Time {
+	if unixMilli <= 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(unixMilli)
+}
+
+// Maps an empty auction id to the default auction
+func auctionIDOrDefault(id string) string {
+	if len(id) == 0 {
+		return defaultAuctionID
+	}
+
+	return id
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ap/DMP3/internal/raft"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Ways the replicas can keep their state in sync
+const (
+	// Every replica applies what the load balancer sends it
+	replicationNone = "none"
+	// Commands go through a Raft log, followers forward writes to the leader
+	replicationRaft = "raft"
+)
+
+const (
+	// Metadata key set on requests a replica forwarded to the leader
+	forwardedKey = "x-forwarded-by"
+)
+
+var (
+	errNoLeader = status.Error(codes.Unavailable, "no known leader to forward the request to")
+)
+
+/*
+Parses a comma separated list of id=address pairs
+*/
+func parsePeers(peers string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, peer := range strings.Split(peers, ",") {
+		if len(peer) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(peer, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return nil, fmt.Errorf("invalid peer %q, expected id=address", peer)
+		}
+
+		result[parts[0]] = parts[1]
+	}
+
+	return result, nil
+}
+
+// How this node dials its peers, with TLS if it was given a certificate
+var transport = grpc.WithInsecure()
+
+/*
+Options for the connections between replicas. The default backoff waits up
+to two minutes before reconnecting to a peer that was down, much longer than
+it takes to decide the peer is dead, so it is capped at the given timeout
+*/
+func peerDialOptions(timeout time.Duration) []grpc.DialOption {
+	return []grpc.DialOption{
+		transport,
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  timeout / 10,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   timeout,
+			},
+			MinConnectTimeout: timeout,
+		}),
+	}
+}
+
+/*
+Joins the Raft cluster. From then on the node state is only changed by
+committed commands, so the bid log in the data directory is not used
+*/
+func (n *Node) StartRaft(peers map[string]string, dataDir string, electionTimeout time.Duration) error {
+	r, err := raft.New(raft.Config{
+		ID:                n.id,
+		Peers:             peers,
+		DataDir:           dataDir,
+		ElectionTimeout:   electionTimeout,
+		HeartbeatInterval: electionTimeout / 3,
+		DialOptions:       peerDialOptions(electionTimeout),
+	}, n)
+	if err != nil {
+		return err
+	}
+
+	n.raft = r
+	return nil
+}
+
+/*
+Applies a committed command, implementing raft.StateMachine
+*/
+func (n *Node) Apply(index uint64, command []byte) interface{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if len(command) == 0 {
+		n.State.Seq = index
+		return nil
+	}
+
+	cmd := &Command{}
+	if err := json.Unmarshal(command, cmd); err != nil {
+		logger.EPrintf("Failed to decode command at index %d, skipping: %v\n", index, err)
+		n.State.Seq = index
+		return nil
+	}
+
+	cmd.Seq = index
+	reply := n.State.Apply(cmd)
+	n.watchers.publish(n.State.takeEvents())
+
+	return reply
+}
+
+/*
+Runs the command through Raft or pushes it to the backups if either is
+enabled, otherwise it is logged and applied right away
+*/
+func (n *Node) submit(ctx context.Context, cmd *Command) (interface{}, error) {
+	if n.passive != nil {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+
+		if !n.passive.isPrimary() {
+			return nil, errNotPrimary
+		}
+
+		// Applied here only once a majority has it, so a primary that fails to
+		// replicate a command never keeps one the group did not commit
+		cmd.Seq = n.State.Seq + 1
+		if err := n.passive.replicate(cmd); err != nil {
+			return nil, err
+		}
+
+		reply, err := n.execute(cmd)
+		if err != nil {
+			// The backups have a command this node does not, one of them has to take over
+			n.passive.stepDown()
+			return nil, err
+		}
+
+		return reply, nil
+	} else if n.raft == nil {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+
+		return n.execute(cmd)
+	}
+
+	command, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.raft.Propose(ctx, command)
+}
+
+/*
+Dials the leader for a request this node cannot serve itself. A request is
+only forwarded once, so replicas with a stale view of the leader cannot bounce
+it between them
+*/
+func (n *Node) dialLeader(ctx context.Context) (*grpc.ClientConn, context.Context, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(forwardedKey)) > 0 {
+		return nil, nil, errNoLeader
+	}
+
+	leader := n.raft.Leader()
+	if len(leader) == 0 || n.raft.LeaderID() == n.id {
+		return nil, nil, errNoLeader
+	}
+
+	logger.IPrintf("Forwarding request to leader %s\n", leader)
+
+	conn, err := grpc.Dial(leader, transport)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, metadata.AppendToOutgoingContext(ctx, forwardedKey, n.id), nil
+}
+
+/*
+Makes sure the leader may serve a read from its own state. It confirms it is
+still the leader and waits until it applied everything committed before the
+read, so a leader that was deposed without noticing does not serve stale data
+*/
+func (n *Node) readBarrier(ctx context.Context) error {
+	if n.raft == nil {
+		return nil
+	}
+
+	if _, err := n.raft.ReadIndex(ctx); err == raft.ErrNotLeader {
+		return status.Error(codes.Unavailable, "lost leadership, the read may be stale")
+	} else if err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return nil
+}
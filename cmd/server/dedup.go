@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	pb "github.com/ap/DMP3/api"
+)
+
+const (
+	// Number of bid outcomes remembered for requests that might be retried
+	maxDedupEntries = 10000
+	// Number of outcomes recorded beyond that before the oldest are evicted all at once
+	dedupEvictBatch = maxDedupEntries / 4
+)
+
+/*
+Outcomes of bids by the request id the client gave them, so a retried bid
+gets the original answer instead of being applied again. It is part of the
+state, so every replica and every restart agrees on what was seen before
+*/
+type DedupTable struct {
+	Outcomes map[string]pb.BidReply_Outcome
+	Reasons  map[string]pb.BidReply_Reason `json:",omitempty"`
+	// Keys in the order they were recorded, the oldest is evicted first
+	Order []string
+}
+
+func NewDedupTable() *DedupTable {
+	return &DedupTable{
+		Outcomes: make(map[string]pb.BidReply_Outcome),
+		Reasons:  make(map[string]pb.BidReply_Reason),
+	}
+}
+
+func (d *DedupTable) Lookup(key string) (*pb.BidReply, bool) {
+	outcome, ok := d.Outcomes[key]
+	if !ok {
+		return nil, false
+	}
+
+	return &pb.BidReply{
+		Outcome: outcome,
+		Reason:  d.Reasons[key],
+	}, true
+}
+
+func (d *DedupTable) Record(key string, reply *pb.BidReply) {
+	// Tables written before rejections had a reason do not have one
+	if d.Reasons == nil {
+		d.Reasons = make(map[string]pb.BidReply_Reason)
+	}
+
+	if _, ok := d.Outcomes[key]; !ok {
+		d.Order = append(d.Order, key)
+	}
+	d.Outcomes[key] = reply.Outcome
+	if reply.Reason != pb.BidReply_NONE {
+		d.Reasons[key] = reply.Reason
+	}
+
+	if len(d.Order) < maxDedupEntries+dedupEvictBatch {
+		return
+	}
+
+	evicted := d.Order[:len(d.Order)-maxDedupEntries]
+	for _, key := range evicted {
+		delete(d.Outcomes, key)
+		delete(d.Reasons, key)
+	}
+
+	// Copied rather than resliced, so the evicted keys do not stay behind in the backing array
+	order := make([]string, maxDedupEntries, maxDedupEntries+dedupEvictBatch)
+	copy(order, d.Order[len(evicted):])
+	d.Order = order
+}
+
+/*
+Request ids are chosen by the clients, so they are only unique per bidder and
+auction. Every part is prefixed with its length, as bidders and request ids
+may contain any separator
+*/
+func dedupKey(auctionID string, bidder string, requestID string) string {
+	key := strings.Builder{}
+	for _, part := range []string{auctionID, bidder, requestID} {
+		key.WriteString(strconv.Itoa(len(part)))
+		key.WriteByte(':')
+		key.WriteString(part)
+	}
+
+	return key.String()
+}
+
+/*
+Applies a bid unless a bid with the same request id was applied before, in
+which case the original outcome is returned
+*/
+func (s *State) applyDedupedBid(cmd *Command) *pb.BidReply {
+	if len(cmd.RequestID) == 0 {
+		return s.applyBid(cmd)
+	}
+
+	// Snapshots written before the table existed do not have one
+	if s.Dedup == nil {
+		s.Dedup = NewDedupTable()
+	}
+
+	key := dedupKey(cmd.AuctionID, cmd.Bidder, cmd.RequestID)
+	if reply, ok := s.Dedup.Lookup(key); ok {
+		logger.IPrintf("Bid request %s by %s in %s was seen before, replying %s again\n", cmd.RequestID, cmd.Bidder, cmd.AuctionID, reply.Outcome)
+		return reply
+	}
+
+	reply := s.applyBid(cmd)
+	s.Dedup.Record(key, reply)
+
+	return reply
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+)
+
+const (
+	defaultStepInterval = time.Second
+)
+
+// DutchSchedule is how the asking price of a Dutch auction drops
+type DutchSchedule struct {
+	StartPrice   int64
+	PriceStep    int64
+	StepInterval time.Duration
+	FloorPrice   int64
+}
+
+func NewDutchSchedule(schedule *pb.DutchSchedule) *DutchSchedule {
+	if schedule == nil {
+		return nil
+	}
+
+	interval := time.Duration(schedule.GetStepIntervalMs()) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultStepInterval
+	}
+
+	floor := money.Amount(schedule.GetFloorPriceAmount(), schedule.GetFloorPrice())
+	if floor < 1 {
+		floor = 1
+	}
+
+	return &DutchSchedule{
+		StartPrice:   money.Amount(schedule.GetStartPriceAmount(), schedule.GetStartPrice()),
+		PriceStep:    money.Amount(schedule.GetPriceStepAmount(), schedule.GetPriceStep()),
+		StepInterval: interval,
+		FloorPrice:   floor,
+	}
+}
+
+func (d *DutchSchedule) Valid() bool {
+	return d != nil && d.StartPrice > 0 && d.PriceStep > 0 && d.StartPrice >= d.FloorPrice
+}
+
+func (d *DutchSchedule) Info(currency string) *pb.DutchSchedule {
+	if d == nil {
+		return nil
+	}
+
+	return &pb.DutchSchedule{
+		StartPrice:       money.Legacy(d.StartPrice),
+		PriceStep:        money.Legacy(d.PriceStep),
+		StepIntervalMs:   d.StepInterval.Milliseconds(),
+		FloorPrice:       money.Legacy(d.FloorPrice),
+		StartPriceAmount: money.New(d.StartPrice, currency),
+		PriceStepAmount:  money.New(d.PriceStep, currency),
+		FloorPriceAmount: money.New(d.FloorPrice, currency),
+	}
+}
+
+/*
+Returns the asking price at the given time. The price clock starts when the
+auction opens, stands still while it is paused and otherwise only depends on
+the schedule, so every replica agrees on the price at the time of a bid
+*/
+func (a *Auction) askingPrice(now time.Time) int64 {
+	d := a.Dutch
+	elapsed := now.Sub(a.StartTime) - a.Paused
+	if elapsed <= 0 {
+		return d.StartPrice
+	}
+
+	steps := int64(elapsed / d.StepInterval)
+	price := d.StartPrice - steps*d.PriceStep
+	if price < d.FloorPrice {
+		return d.FloorPrice
+	}
+
+	return price
+}
+
+/*
+Accepts the asking price at the time of the bid if the bid is at least that
+much. The bidder wins at the asking price, even if they offered more, and the
+auction closes right away like it does when it reaches its end time
+*/
+func (a *Auction) acceptAskingPrice(cmd *Command) *pb.BidReply {
+	bidTime := time.Unix(0, cmd.Time)
+	price := a.askingPrice(bidTime)
+
+	if cmd.Bid < price {
+		logger.IPrintf("Bid %d by %s in %s is below the asking price %d, rejecting\n", cmd.Bid, cmd.Bidder, a.ID, price)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_BELOW_ASKING_PRICE)
+	}
+
+	logger.IPrintf("%s accepted the asking price %d in %s\n", cmd.Bidder, price, a.ID)
+	a.HighestBid = price
+	a.HighestBidder = cmd.Bidder
+	a.HighestBidTime = bidTime
+	a.transition(pb.AuctionState_CLOSING, bidTime)
+
+	return &pb.BidReply{
+		Outcome: pb.BidReply_SUCCESS,
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+	"github.com/ap/DMP3/internal/raft"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// BidRecord is a bid in the history of an auction
+type BidRecord struct {
+	ID       uint64
+	Bidder   string
+	Amount   int64
+	Time     time.Time
+	Outcome  pb.BidReply_Outcome
+	Reason   pb.BidReply_Reason `json:",omitempty"`
+	Price    int64              `json:",omitempty"`
+	Repaired bool               `json:",omitempty"`
+	// Currency the bidder gave, which a rejected bid may not share with the auction
+	Currency string `json:",omitempty"`
+	// Request id and secret proxy maximum the bidder gave
+	RequestID string `json:",omitempty"`
+	Max       int64  `json:",omitempty"`
+	Retracted bool   `json:",omitempty"`
+	// ID of the bid retracted, if this is a retraction rather than a bid
+	Retracts uint64 `json:",omitempty"`
+	Note     string `json:",omitempty"`
+	Approved bool   `json:",omitempty"`
+}
+
+// Converts the record, its amounts are in the currency of the auction unless the bidder gave another
+func (r *BidRecord) Info(currency string) *pb.BidRecord {
+	bidCurrency := currency
+	if len(r.Currency) > 0 {
+		bidCurrency = r.Currency
+	}
+
+	return &pb.BidRecord{
+		Id:          r.ID,
+		Bidder:      r.Bidder,
+		Amount:      money.Legacy(r.Amount),
+		Time:        r.Time.UnixMilli(),
+		Outcome:     r.Outcome,
+		Reason:      r.Reason,
+		Price:       money.Legacy(r.Price),
+		Repaired:    r.Repaired,
+		RequestId:   r.RequestID,
+		Retracted:   r.Retracted,
+		Retracts:    r.Retracts,
+		Note:        r.Note,
+		Approved:    r.Approved,
+		BidAmount:   money.New(r.Amount, bidCurrency),
+		PriceAmount: money.New(r.Price, currency),
+	}
+}
+
+// Appends a bid and what became of it to the history, in the order bids are applied
+func (a *Auction) record(bidder string, amount int64, bidTime time.Time, reply *pb.BidReply, repaired bool) *BidRecord {
+	var price int64
+	if a.Type != pb.AuctionType_VICKREY {
+		price = a.HighestBid
+	}
+
+	record := &BidRecord{
+		ID:       uint64(len(a.Bids) + 1),
+		Bidder:   bidder,
+		Amount:   amount,
+		Time:     bidTime,
+		Outcome:  reply.Outcome,
+		Reason:   reply.Reason,
+		Price:    price,
+		Repaired: repaired,
+	}
+	a.Bids = append(a.Bids, record)
+
+	return record
+}
+
+/*
+Encodes the position of the last bid on a page. The next page starts right
+after it, so bids added in between do not shift the pages
+*/
+func encodeCursor(record *BidRecord) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%d", record.Amount, record.ID)))
+}
+
+func decodeCursor(cursor string) (int64, uint64, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	parts := strings.SplitN(string(decoded), "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("malformed cursor")
+	}
+
+	amount, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return amount, id, nil
+}
+
+/*
+Lists a page of the bids matching the request. Bids are ordered by time or
+amount, with the order they were applied in breaking ties. The bids of a
+sealed-bid auction are only listed once it closed
+*/
+func (a *Auction) listBids(req *pb.ListBidsRequest) (*pb.ListBidsReply, error) {
+	if a.sealed() {
+		return nil, status.Error(codes.FailedPrecondition, "bids are sealed until the auction closes")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	} else if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	fromTime := timeOrZero(req.GetFromTime())
+	toTime := timeOrZero(req.GetToTime())
+
+	matching := make([]*BidRecord, 0)
+	for _, record := range a.Bids {
+		if len(req.GetBidder()) > 0 && record.Bidder != req.GetBidder() {
+			continue
+		}
+		if record.Time.Before(fromTime) || (!toTime.IsZero() && !record.Time.Before(toTime)) {
+			continue
+		}
+
+		matching = append(matching, record)
+	}
+
+	byAmount := req.GetSortBy() == pb.ListBidsRequest_AMOUNT
+	less := func(x *BidRecord, y *BidRecord) bool {
+		if byAmount && x.Amount != y.Amount {
+			return x.Amount < y.Amount
+		}
+		return x.ID < y.ID
+	}
+	if req.GetDescending() {
+		ascending := less
+		less = func(x *BidRecord, y *BidRecord) bool {
+			return ascending(y, x)
+		}
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return less(matching[i], matching[j])
+	})
+
+	start := 0
+	if len(req.GetCursor()) > 0 {
+		amount, id, err := decodeCursor(req.GetCursor())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %v", err)
+		}
+
+		last := &BidRecord{Amount: amount, ID: id}
+		start = sort.Search(len(matching), func(i int) bool {
+			return less(last, matching[i])
+		})
+	}
+
+	end := start + pageSize
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	reply := &pb.ListBidsReply{
+		Bids: make([]*pb.BidRecord, 0, end-start),
+	}
+	for _, record := range matching[start:end] {
+		reply.Bids = append(reply.Bids, record.Info(a.currency()))
+	}
+	if end < len(matching) {
+		reply.NextCursor = encodeCursor(matching[end-1])
+	}
+
+	return reply, nil
+}
+
+func (n *Node) ListBids(ctx context.Context, req *pb.ListBidsRequest) (*pb.ListBidsReply, error) {
+
+	// Only the leader is guaranteed to have applied every acknowledged bid
+	if n.raft != nil && n.raft.Role() != raft.Leader {
+		conn, ctx, err := n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return pb.NewAuctionClient(conn).ListBids(ctx, req)
+	}
+	if err := n.readBarrier(ctx); err != nil {
+		return nil, err
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	auctionID := auctionIDOrDefault(req.GetAuctionId())
+	auction, ok := n.State.Auctions[auctionID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown auction %s", auctionID)
+	}
+
+	return auction.listBids(req)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+	"github.com/ap/DMP3/internal/raft"
+)
+
+var (
+	errInvalidTransition = errors.New("invalid auction state transition")
+	errUnknownAuction    = errors.New("unknown auction")
+)
+
+// States an auction may move to from each state, settled and cancelled are final
+var transitions = map[pb.AuctionState][]pb.AuctionState{
+	pb.AuctionState_SCHEDULED: {pb.AuctionState_OPEN, pb.AuctionState_CANCELLED},
+	pb.AuctionState_OPEN:      {pb.AuctionState_CLOSING, pb.AuctionState_CANCELLED, pb.AuctionState_PAUSED},
+	pb.AuctionState_PAUSED:    {pb.AuctionState_OPEN, pb.AuctionState_CLOSING, pb.AuctionState_CANCELLED},
+	pb.AuctionState_CLOSING:   {pb.AuctionState_CLOSED, pb.AuctionState_CANCELLED},
+	pb.AuctionState_CLOSED:    {pb.AuctionState_SETTLED},
+}
+
+func canTransition(from pb.AuctionState, to pb.AuctionState) bool {
+	for _, state := range transitions[from] {
+		if state == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Moves the auction to the given state if the transition is allowed, at is the
+time the transition takes effect
+*/
+func (a *Auction) transition(to pb.AuctionState, at time.Time) error {
+	if !canTransition(a.State, to) {
+		return fmt.Errorf("%w from %s to %s in %s", errInvalidTransition, a.State, to, a.ID)
+	}
+
+	logger.IPrintf("Auction %s is now %s, was %s\n", a.ID, to, a.State)
+	a.State = to
+	a.StateChanged = at
+
+	// No more bids are accepted from here on, so the sealed bids can be opened
+	if to == pb.AuctionState_CLOSING && a.Type == pb.AuctionType_VICKREY {
+		a.resolveSealedBids()
+	}
+
+	return nil
+}
+
+/*
+Makes the transitions that only depend on the time: a scheduled auction opens
+at its start time and an open one starts closing at its end time. Only ever
+called with the time of a command, so every replica makes them at the same
+point in the log
+*/
+func (a *Auction) advance(now time.Time) {
+	if a.State == pb.AuctionState_SCHEDULED && !now.Before(a.StartTime) {
+		a.transition(pb.AuctionState_OPEN, a.StartTime)
+	}
+
+	if a.State == pb.AuctionState_OPEN && !a.EndTime.IsZero() && !now.Before(a.EndTime) {
+		a.transition(pb.AuctionState_CLOSING, a.EndTime)
+	}
+}
+
+/*
+Returns the state the auction is due to move to at the given time, if any,
+and the time it was due at. An auction is closed once it has been closing for
+closingPeriod, and settled right after
+*/
+func (a *Auction) due(now time.Time, closingPeriod time.Duration) (pb.AuctionState, time.Time, bool) {
+	switch a.State {
+	case pb.AuctionState_SCHEDULED:
+		return pb.AuctionState_OPEN, a.StartTime, !now.Before(a.StartTime)
+	case pb.AuctionState_OPEN:
+		return pb.AuctionState_CLOSING, a.EndTime, !a.EndTime.IsZero() && !now.Before(a.EndTime)
+	case pb.AuctionState_CLOSING:
+		closed := a.StateChanged.Add(closingPeriod)
+		return pb.AuctionState_CLOSED, closed, !now.Before(closed)
+	case pb.AuctionState_CLOSED:
+		return pb.AuctionState_SETTLED, a.StateChanged, true
+	default:
+		return a.State, now, false
+	}
+}
+
+/*
+Creates the command for a time driven transition, taking effect at the time
+it was due rather than when the node got to it. Replicas coordinated by
+quorum each issue their own, this way they still close and settle the
+auction at the same time and book the same ledger entries
+*/
+func NewTransitionCommand(auctionID string, state pb.AuctionState, at time.Time) *Command {
+	// Auctions from snapshots that did not keep the time of their last transition
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	return &Command{
+		Op:        opTransition,
+		Time:      at.UnixNano(),
+		AuctionID: auctionID,
+		State:     state,
+	}
+}
+
+/*
+Moves an auction to the state in the command. Moving it to the state it is
+already in is not an error, the time driven transitions may have got there
+before the command did
+*/
+func (s *State) applyTransition(cmd *Command) error {
+	auction, ok := s.Auctions[cmd.AuctionID]
+	if !ok {
+		logger.IPrintf("Transition of unknown auction %s, rejecting\n", cmd.AuctionID)
+		return errUnknownAuction
+	}
+
+	if auction.State == cmd.State {
+		return nil
+	}
+
+	if err := auction.transition(cmd.State, time.Unix(0, cmd.Time)); err != nil {
+		logger.IPrintf("Rejecting transition: %v\n", err)
+		return err
+	}
+
+	if cmd.State == pb.AuctionState_SETTLED && auction.reserveMet() {
+		logger.IPrintf("Settled %s, %s won and pays %s\n", auction.ID, auction.HighestBidder, money.String(auction.Result(time.Unix(0, cmd.Time)).PriceAmount))
+	} else if cmd.State == pb.AuctionState_SETTLED && len(auction.HighestBidder) > 0 {
+		logger.IPrintf("Settled %s, the reserve price was not met so it is not sold\n", auction.ID)
+	}
+
+	if cmd.State == pb.AuctionState_SETTLED {
+		s.settle(auction, time.Unix(0, cmd.Time))
+	}
+
+	return nil
+}
+
+/*
+Whether this node issues the lifecycle commands. With Raft or primary-backup
+only the leader or primary does, the others apply what it issued
+*/
+func (n *Node) issuesLifecycle() bool {
+	if n.raft != nil {
+		return n.raft.Role() == raft.Leader
+	} else if n.passive != nil {
+		return n.passive.isPrimary()
+	}
+
+	return true
+}
+
+/*
+Drives the auction lifecycle. Every interval the default auction is created
+if it does not exist yet, and every auction due to move to its next state is
+moved there through a command, so the transition is logged and replicated
+like any other change. Replicas coordinated by the load balancer would each
+create the default auction with their own times, so there the load balancer
+creates it like any other auction
+*/
+func (n *Node) runLifecycle(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		if !n.issuesLifecycle() {
+			continue
+		}
+
+		n.lock.RLock()
+		_, hasDefault := n.State.Auctions[defaultAuctionID]
+		createDefault := !hasDefault && (n.raft != nil || n.passive != nil)
+		now := time.Now()
+		due := make(map[string]*Command)
+		for id, auction := range n.State.Auctions {
+			if state, at, ok := auction.due(now, n.closingPeriod); ok {
+				due[id] = NewTransitionCommand(id, state, at)
+			}
+		}
+		n.lock.RUnlock()
+
+		if createDefault {
+			logger.IPrintf("Creating the default auction, running for %s\n", n.auctionDuration)
+			n.submitLifecycle(NewCreateAuctionCommand(&pb.CreateAuctionRequest{AuctionId: defaultAuctionID}, n.auctionDuration, n.feeBps))
+		}
+
+		ids := make([]string, 0, len(due))
+		for id := range due {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			n.submitLifecycle(due[id])
+		}
+	}
+}
+
+func (n *Node) submitLifecycle(cmd *Command) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := n.submit(ctx, cmd); err != nil && err != raft.ErrNotLeader && err != errNotPrimary {
+		logger.EPrintf("Failed to submit %s of %s: %v\n", cmd.Op, cmd.AuctionID, err)
+	}
+}
@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"flag"
 	"net"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/certs"
 	"github.com/ap/DMP3/internal/logging"
+	"github.com/ap/DMP3/internal/raft"
+	"github.com/ap/DMP3/internal/wal"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -15,9 +24,33 @@ const (
 )
 
 type Node struct {
-	HighestBid int32
-	lock       sync.RWMutex
+	id            string
+	State         *State
+	log           *wal.Log
+	raft          *raft.Raft
+	passive       *passiveReplication
+	recent        []*Command
+	snapshotEvery int
+	sinceSnapshot int
+	// Used by auctions created without a duration, including the default auction
+	auctionDuration time.Duration
+	// How long an auction stays closing before its result is final
+	closingPeriod time.Duration
+	// Whether bidders need an account to bid
+	requireAccount bool
+	// Whether the replicas each apply bids in the order they arrive in, which rules out proxy bidding and retractions
+	unorderedBids bool
+	// Platform fee of auctions created without one, in basis points
+	feeBps int64
+	// Whether callers prove their role with a certificate, over plaintext the
+	// load balancer sends adminToken instead
+	mutualTLS  bool
+	adminToken string
+	// Streams auction events to watchers
+	watchers *watchHub
+	lock     sync.RWMutex
 	pb.UnimplementedAuctionServer
+	pb.UnimplementedReplicaServer
 }
 
 var (
@@ -25,24 +58,123 @@ var (
 )
 
 func main() {
+	addr := flag.String("addr", ip, "Address to listen on")
+	dataDir := flag.String("dataDir", "data", "Directory to keep the bid log and snapshots in, state is kept in memory only if empty")
+	snapshotEvery := flag.Int("snapshotEvery", 1000, "Number of logged commands between snapshots")
+	replication := flag.String("replication", replicationNone, "How replicas keep in sync: none (coordinated by the load balancer), raft or primary-backup")
+	id := flag.String("id", "", "Identifier of this node among the peers")
+	peersStr := flag.String("peers", "", "Comma separated id=address of every node in the cluster, including this one. Used to catch up after a restart if replication is none")
+	electionTimeout := flag.Duration("electionTimeout", 300*time.Millisecond, "Minimum time without a leader or primary before taking over")
+	auctionDuration := flag.Duration("auctionDuration", time.Minute, "How long auctions created without a duration run, including the default auction")
+	closingPeriod := flag.Duration("closingPeriod", 2*time.Second, "How long an auction stays closing after its end time before the result is final")
+	lifecycleInterval := flag.Duration("lifecycleInterval", 250*time.Millisecond, "How often auctions are checked for state transitions")
+	adminToken := flag.String("adminToken", "", "Token auctioneers must send to use the admin service, which is disabled if empty")
+	watchBuffer := flag.Int("watchBuffer", defaultWatchBuffer, "Number of recent events kept per auction for watchers that resume or fall behind, older ones get a snapshot")
+	requireAccount := flag.Bool("requireAccount", false, "Reject bids from bidders without an account, bids from bidders with one are always checked against their funds")
+	feeBps := flag.Int64("feeBps", 500, "Platform fee taken from the price of auctions created without one, in basis points")
+	tlsCert := flag.String("tlsCert", "", "Certificate to serve and dial peers with, plaintext if empty. Reloaded when the file changes")
+	tlsKey := flag.String("tlsKey", "", "Key of --tlsCert")
+	tlsCA := flag.String("tlsCA", "", "CA that signs the certificates of the load balancers and peers, only they may connect")
+	flag.Parse()
+
 	node := &Node{
-		HighestBid: 0,
-		lock:       sync.RWMutex{},
+		id:              *id,
+		State:           NewState(),
+		snapshotEvery:   *snapshotEvery,
+		auctionDuration: *auctionDuration,
+		closingPeriod:   *closingPeriod,
+		requireAccount:  *requireAccount,
+		feeBps:          *feeBps,
+		watchers:        newWatchHub(*watchBuffer),
+		lock:            sync.RWMutex{},
+	}
+
+	var serverOptions []grpc.ServerOption
+	if len(*tlsCert) > 0 || len(*tlsKey) > 0 || len(*tlsCA) > 0 {
+		if len(*tlsCert) == 0 || len(*tlsKey) == 0 || len(*tlsCA) == 0 {
+			logger.EPrintf("TLS needs --tlsCert, --tlsKey and --tlsCA\n")
+			return
+		}
+
+		reloader, err := certs.NewReloader(*tlsCert, *tlsKey, *tlsCA)
+		if err != nil {
+			logger.EPrintf("Failed to load the certificates: %v\n", err)
+			return
+		}
+
+		// Bidders go through a load balancer, so only load balancers and peers are let in
+		serverOptions = append(serverOptions, grpc.Creds(reloader.ServerCredentials(certs.RoleLB, certs.RoleReplica)))
+		transport = grpc.WithTransportCredentials(reloader.ClientCredentials())
+		node.mutualTLS = true
+		logger.IPrintf("Serving mutual TLS\n")
+	} else if *replication == replicationNone && len(*adminToken) == 0 {
+		logger.IPrintf("Serving plaintext without --adminToken, repairs are refused as no caller can prove it is a load balancer\n")
+	}
+	node.adminToken = *adminToken
+
+	peers, err := parsePeers(*peersStr)
+	if err != nil {
+		logger.EPrintf("Failed to parse peers: %v\n", err)
+		return
 	}
+	node.unorderedBids = *replication == replicationNone && len(peers) > 1
+
+	switch *replication {
+	case replicationNone, replicationPrimaryBackup:
+		if len(*dataDir) > 0 {
+			if err := node.Recover(*dataDir); err != nil {
+				logger.EPrintf("Failed to recover state from %s: %v\n", *dataDir, err)
+				return
+			}
+		}
 
-	node.StartServer()
+		if *replication == replicationPrimaryBackup {
+			if err := node.StartPrimaryBackup(peers, *electionTimeout); err != nil {
+				logger.EPrintf("Failed to start primary-backup: %v\n", err)
+				return
+			}
+		}
+
+		// Catch up before serving, so the load balancer never reads what this node missed while it was down
+		if len(peers) > 1 {
+			if err := node.CatchUp(peers, time.Second); err != nil {
+				logger.EPrintf("Failed to catch up with the peers, serving the local state: %v\n", err)
+			}
+		}
+	case replicationRaft:
+		raftDir := ""
+		if len(*dataDir) > 0 {
+			raftDir = filepath.Join(*dataDir, "raft")
+		}
+
+		if err := node.StartRaft(peers, raftDir, *electionTimeout); err != nil {
+			logger.EPrintf("Failed to start raft: %v\n", err)
+			return
+		}
+	default:
+		logger.EPrintf("Unknown replication %q\n", *replication)
+		return
+	}
+
+	go node.runLifecycle(*lifecycleInterval)
+	node.StartServer(*addr, *adminToken, serverOptions...)
 }
 
-func (n *Node) StartServer() {
+func (n *Node) StartServer(addr string, adminToken string, options ...grpc.ServerOption) {
 	logger.IPrintf("Starting server\n")
 
-	lis, err := net.Listen("tcp", ip)
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		logger.EPrintf("Failed to listen: %v\n", err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(options...)
 	pb.RegisterAuctionServer(s, n)
+	pb.RegisterReplicaServer(s, n)
+	pb.RegisterAuctionAdminServer(s, &adminServer{n: n, token: adminToken})
+	if n.raft != nil {
+		pb.RegisterRaftServer(s, n.raft)
+	}
 
 	logger.IPrintf("Server listening on %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
@@ -50,40 +182,123 @@ func (n *Node) StartServer() {
 	}
 }
 
-func (n *Node) Bid(_ context.Context, req *pb.BidRequest) (*pb.BidReply, error) {
-	n.lock.Lock()
-	defer n.lock.Unlock()
-	
-	logger.IPrintf("Retrieved bid request: %v, highest bid at this moment: %d\n", req, n.HighestBid)
-	newBid := req.GetBid()
+func (n *Node) Bid(ctx context.Context, req *pb.BidRequest) (*pb.BidReply, error) {
+	cmd := NewBidCommand(req)
+	cmd.RequireAccount = n.requireAccount
+
+	// Every replica rejects it the same way, whatever order the bids reach them in
+	if n.unorderedBids && cmd.MaxBid > 0 {
+		logger.IPrintf("Bid by %s in %s has a maximum but bids are not ordered, rejecting\n", cmd.Bidder, cmd.AuctionID)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_PROXY_NOT_SUPPORTED), nil
+	}
 
-	if n.HighestBid < newBid {
-		logger.IPrintf("Setting new highest value. Old: %d, new %d\n", n.HighestBid, newBid)
-		n.HighestBid = newBid
+	reply, err := n.submit(ctx, cmd)
+	if err == raft.ErrNotLeader {
+		conn, ctx, err := n.dialLeader(ctx)
+		if err != nil {
+			return &pb.BidReply{
+				Outcome: pb.BidReply_UNAVAILABLE,
+			}, nil
+		}
+		defer conn.Close()
 
+		return pb.NewAuctionClient(conn).Bid(ctx, req)
+	} else if err == errNotPrimary {
+		return nil, err
+	} else if err != nil && n.raft != nil {
+		// The command may still be committed later, so the outcome is unknown rather than failed
+		logger.EPrintf("Bid was not committed: %v\n", err)
 		return &pb.BidReply{
-			Outcome: pb.BidReply_SUCCESS,
+			Outcome: pb.BidReply_UNAVAILABLE,
 		}, nil
-	} else if n.HighestBid >= newBid {
-		logger.IPrintf("New bid is below the highest bid. Highest: %d, new %d\n", n.HighestBid, newBid)
+	} else if err != nil {
 		return &pb.BidReply{
-			Outcome: pb.BidReply_FAIL,
+			Outcome: pb.BidReply_EXCEPTION,
 		}, nil
 	}
 
-	logger.EPrintf("Something bad happened. Highest: %d, new %d\n", n.HighestBid, newBid)
-	return &pb.BidReply{
-		Outcome: pb.BidReply_EXCEPTION,
-	}, nil
+	return reply.(*pb.BidReply), nil
 }
 
-func (n *Node) GetResult(_ context.Context, _ *pb.ResultRequest) (*pb.ResultReply, error) {
+func (n *Node) GetResult(ctx context.Context, req *pb.ResultRequest) (*pb.ResultReply, error) {
+
+	// Only the leader is guaranteed to have applied every acknowledged bid
+	if n.raft != nil && n.raft.Role() != raft.Leader {
+		conn, ctx, err := n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return pb.NewAuctionClient(conn).GetResult(ctx, req)
+	}
+	if err := n.readBarrier(ctx); err != nil {
+		return nil, err
+	}
 
 	n.lock.RLock()
 	defer n.lock.RUnlock()
 
-	logger.IPrintf("Retrieved get request. Highest bid at this moment: %d\n", n.HighestBid)
-	return &pb.ResultReply{
-		Result: n.HighestBid,
+	auctionID := auctionIDOrDefault(req.GetAuctionId())
+	auction, ok := n.State.Auctions[auctionID]
+	if !ok {
+		logger.IPrintf("Retrieved get request for unknown auction %s\n", auctionID)
+		return &pb.ResultReply{}, nil
+	}
+
+	logger.IPrintf("Retrieved get request. Highest bid in %s at this moment: %d by %s\n", auctionID, auction.HighestBid, auction.HighestBidder)
+
+	return auction.Result(time.Now()), nil
+}
+
+func (n *Node) ListAuctions(_ context.Context, _ *pb.ListAuctionsRequest) (*pb.ListAuctionsReply, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	ids := make([]string, 0, len(n.State.Auctions))
+	for id := range n.State.Auctions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	auctions := make([]*pb.AuctionInfo, 0, len(ids))
+	for _, id := range ids {
+		auctions = append(auctions, n.State.Auctions[id].Info())
+	}
+
+	return &pb.ListAuctionsReply{
+		Auctions: auctions,
 	}, nil
 }
+
+/*
+Repairs overwrite results, so they are only taken from a load balancer. Over
+mutual TLS it proves its role with its certificate, over plaintext it has no
+certificate and sends the admin token instead
+*/
+func (n *Node) authorizeRepair(ctx context.Context) error {
+	if !n.mutualTLS {
+		return authorizeAdmin(ctx, n.adminToken)
+	}
+
+	if !certs.PeerHasRole(ctx, certs.RoleLB) {
+		return status.Error(codes.PermissionDenied, "repairs are only taken from load balancer certificates")
+	}
+
+	return nil
+}
+
+func (n *Node) Repair(ctx context.Context, req *pb.RepairRequest) (*pb.RepairReply, error) {
+	if err := n.authorizeRepair(ctx); err != nil {
+		return nil, err
+	}
+
+	reply, err := n.submit(ctx, NewRepairCommand(req))
+	if err == raft.ErrNotLeader {
+		return nil, status.Error(codes.FailedPrecondition, "repairs are only accepted by the leader")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return reply.(*pb.RepairReply), nil
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The primary processes every write and pushes it to the backups before replying
+	replicationPrimaryBackup = "primary-backup"
+)
+
+var (
+	errNotPrimary = status.Error(codes.FailedPrecondition, "not the primary")
+)
+
+/*
+Passive replication state of a node. The view is bumped every time a backup
+takes over, so messages from a primary that has been replaced are rejected
+*/
+type passiveReplication struct {
+	id            string
+	peers         map[string]string
+	order         []string
+	clients       map[string]pb.ReplicaClient
+	timeout       time.Duration
+	view          uint64
+	primaryID     string
+	backups       map[string]bool
+	lastHeartbeat time.Time
+	// Set once a heartbeat shows the primary applied commands this node missed
+	stale bool
+	// Set once this node applied a command the group may not have, it then
+	// fetches the whole state of the primary rather than the commands it missed
+	diverged bool
+	lock     sync.Mutex
+}
+
+/*
+Joins the primary-backup group. The first node in sorted order is the primary
+of view 0, unless one of the peers already knows a newer view
+*/
+func (n *Node) StartPrimaryBackup(peers map[string]string, timeout time.Duration) error {
+	order := make([]string, 0, len(peers))
+	for id := range peers {
+		order = append(order, id)
+	}
+	sort.Strings(order)
+
+	p := &passiveReplication{
+		id:            n.id,
+		peers:         peers,
+		order:         order,
+		clients:       make(map[string]pb.ReplicaClient),
+		timeout:       timeout,
+		view:          0,
+		primaryID:     order[0],
+		backups:       make(map[string]bool),
+		lastHeartbeat: time.Now(),
+	}
+
+	for id, addr := range peers {
+		if id == n.id {
+			continue
+		}
+
+		conn, err := grpc.Dial(addr, peerDialOptions(timeout)...)
+		if err != nil {
+			return err
+		}
+		p.clients[id] = pb.NewReplicaClient(conn)
+		p.backups[id] = true
+	}
+
+	p.discoverView()
+	logger.IPrintf("Starting primary-backup in view %d with primary %s\n", p.view, p.primaryID)
+
+	n.passive = p
+	go n.monitorPrimary()
+
+	return nil
+}
+
+/*
+Asks the peers which view they are in and adopts the newest one, so a
+restarted node does not claim to be the primary of a view long gone
+*/
+func (p *passiveReplication) discoverView() {
+	for id, client := range p.clients {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		reply, err := client.GetPrimary(ctx, &pb.PrimaryRequest{})
+		cancel()
+
+		if err != nil || len(reply.PrimaryId) == 0 {
+			continue
+		}
+
+		if reply.View > p.view || (reply.View == p.view && p.primaryID == p.id) {
+			logger.IPrintf("Peer %s is in view %d with primary %s\n", id, reply.View, reply.PrimaryId)
+			p.view = reply.View
+			p.primaryID = reply.PrimaryId
+		}
+	}
+}
+
+func (p *passiveReplication) isPrimary() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.primaryID == p.id
+}
+
+/*
+Accepts a message from a primary if it is at least as new as the current
+view. Two backups taking over at the same time end up in the same view, the
+one first in order keeps it. The caller must hold the lock
+*/
+func (p *passiveReplication) accept(view uint64, primaryID string) bool {
+	if view < p.view {
+		return false
+	}
+
+	if view == p.view && p.primaryID == p.id && primaryID != p.id && p.id < primaryID {
+		return false
+	}
+
+	if view > p.view || p.primaryID != primaryID {
+		logger.IPrintf("Following primary %s in view %d\n", primaryID, view)
+	}
+
+	p.view = view
+	p.primaryID = primaryID
+	p.lastHeartbeat = time.Now()
+
+	return true
+}
+
+/*
+Pushes a command to every backup still in the group and waits for them to
+apply it. Backups that do not acknowledge it are dropped from the group, and
+the node steps down if a backup is already in a newer view. The command only
+succeeds once a majority of the nodes, counting this one, would have applied
+it, and the primary applies it only then. A primary cut off from the others
+steps down rather than carry on alone, so it cannot take writes while a
+backup that took over takes others. The caller must hold the node lock so
+commands are pushed in order
+*/
+func (p *passiveReplication) replicate(cmd *Command) error {
+	command, err := json.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	req := &pb.ReplicateRequest{
+		View:      p.view,
+		PrimaryId: p.id,
+		Command:   command,
+	}
+	backups := make([]string, 0, len(p.backups))
+	for id, live := range p.backups {
+		if live {
+			backups = append(backups, id)
+		}
+	}
+	p.lock.Unlock()
+
+	acks := 1
+	wg := sync.WaitGroup{}
+	for _, id := range backups {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+			defer cancel()
+
+			reply, err := p.clients[id].Replicate(ctx, req)
+
+			p.lock.Lock()
+			defer p.lock.Unlock()
+
+			if err != nil {
+				logger.EPrintf("Backup %s did not acknowledge seq %d, dropping it: %v\n", id, cmd.Seq, err)
+				p.backups[id] = false
+			} else if reply.View > p.view {
+				logger.IPrintf("Backup %s is in newer view %d, stepping down\n", id, reply.View)
+				p.view = reply.View
+				p.primaryID = ""
+			} else if !reply.Ok {
+				logger.EPrintf("Backup %s is at seq %d and cannot apply seq %d, dropping it\n", id, reply.Seq, cmd.Seq)
+				p.backups[id] = false
+			} else {
+				acks++
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.primaryID == p.id && acks < p.majority() {
+		logger.EPrintf("Only %d nodes applied seq %d and %d are needed, stepping down\n", acks, cmd.Seq, p.majority())
+		p.primaryID = ""
+		p.lastHeartbeat = time.Now()
+	}
+
+	if p.primaryID != p.id {
+		// Not applied here, but the backups that acknowledged it did, so the outcome is unknown to the caller
+		return errNotPrimary
+	}
+
+	return nil
+}
+
+/*
+Steps down after the backups applied a command this node could not, so it
+fetches the state of the next primary rather than carry on without it
+*/
+func (p *passiveReplication) stepDown() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	logger.EPrintf("Failed to apply a command the backups applied, stepping down\n")
+	p.primaryID = ""
+	p.lastHeartbeat = time.Now()
+	p.diverged = true
+}
+
+// Number of nodes making up a majority of the group
+func (p *passiveReplication) majority() int {
+	return len(p.peers)/2 + 1
+}
+
+/*
+Sends heartbeats while this node is the primary. While it is a backup it
+catches up once it notices it missed commands, and takes over once the
+primary has been silent for too long
+*/
+func (n *Node) monitorPrimary() {
+	p := n.passive
+	ticker := time.NewTicker(p.timeout / 3)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if p.isPrimary() {
+			n.sendHeartbeats()
+			continue
+		}
+
+		p.lock.Lock()
+		behind := (p.stale || p.diverged) && len(p.primaryID) > 0
+		p.lock.Unlock()
+
+		if behind {
+			err := n.CatchUp(p.peers, p.timeout)
+			if err == nil {
+				continue
+			}
+			// A primary that cannot be reached may be gone, in which case this node may have to take over
+			logger.EPrintf("Failed to catch up with the primary: %v\n", err)
+		}
+
+		p.lock.Lock()
+		due := time.Since(p.lastHeartbeat) > p.takeoverDelay()
+		p.lock.Unlock()
+
+		if !due || !n.mayTakeOver() {
+			continue
+		}
+
+		p.lock.Lock()
+		if time.Since(p.lastHeartbeat) > p.takeoverDelay() {
+			p.view++
+			p.primaryID = p.id
+			p.stale = false
+			p.diverged = false
+			for id := range p.backups {
+				p.backups[id] = true
+			}
+			logger.IPrintf("Primary is silent, taking over in view %d\n", p.view)
+		}
+		p.lock.Unlock()
+	}
+}
+
+/*
+Whether this node may take over from a silent primary. It needs a majority of
+the nodes, counting itself, to answer, so a node cut off from the others
+never makes itself primary. And none of them may have applied more commands
+than this node, the one that did takes over instead so no command a majority
+acknowledged is lost
+*/
+func (n *Node) mayTakeOver() bool {
+	p := n.passive
+
+	n.lock.RLock()
+	seq := n.State.Seq
+	n.lock.RUnlock()
+
+	p.lock.Lock()
+	diverged := p.diverged
+	p.lock.Unlock()
+
+	reachable := 1
+	for id, client := range p.clients {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout/3)
+		reply, err := client.Status(ctx, &pb.StatusRequest{})
+		cancel()
+
+		if err != nil {
+			continue
+		}
+
+		reachable++
+		// A node that may have applied commands the group never agreed on also leaves it to a peer as far along
+		if reply.Seq > seq || (diverged && reply.Seq == seq) {
+			logger.IPrintf("Peer %s is further along at seq %d than seq %d, leaving the takeover to it\n", id, reply.Seq, seq)
+			return false
+		}
+	}
+
+	if reachable < p.majority() {
+		logger.EPrintf("Only %d nodes answer and %d are needed, not taking over\n", reachable, p.majority())
+		return false
+	}
+
+	return true
+}
+
+/*
+How long a backup waits for the primary before taking over. Backups wait
+longer the further down the order they are, so the first one in line
+normally takes over before the others notice. The caller must hold the lock
+*/
+func (p *passiveReplication) takeoverDelay() time.Duration {
+	rank := 0
+	for _, id := range p.order {
+		if id == p.id {
+			break
+		}
+		if id != p.primaryID {
+			rank++
+		}
+	}
+
+	return p.timeout * time.Duration(rank+1)
+}
+
+func (n *Node) sendHeartbeats() {
+	p := n.passive
+
+	n.lock.RLock()
+	seq := n.State.Seq
+	n.lock.RUnlock()
+
+	p.lock.Lock()
+	req := &pb.HeartbeatRequest{
+		View:      p.view,
+		PrimaryId: p.id,
+		Seq:       seq,
+	}
+	p.lock.Unlock()
+
+	for id, client := range p.clients {
+		go func(id string, client pb.ReplicaClient) {
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout/3)
+			defer cancel()
+
+			reply, err := client.Heartbeat(ctx, req)
+			if err != nil {
+				return
+			}
+
+			p.lock.Lock()
+			defer p.lock.Unlock()
+
+			if reply.View > p.view {
+				logger.IPrintf("Peer %s is in newer view %d, stepping down\n", id, reply.View)
+				p.view = reply.View
+				p.primaryID = ""
+				p.lastHeartbeat = time.Now()
+			} else if reply.View == p.view && reply.Seq == req.Seq && !p.backups[id] {
+				logger.IPrintf("Backup %s caught up at seq %d, adding it back\n", id, reply.Seq)
+				p.backups[id] = true
+			}
+		}(id, client)
+	}
+}
+
+func (n *Node) Replicate(_ context.Context, req *pb.ReplicateRequest) (*pb.ReplicateReply, error) {
+	if n.passive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "primary-backup replication is not enabled")
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	p := n.passive
+	p.lock.Lock()
+	accepted := p.accept(req.View, req.PrimaryId)
+	view := p.view
+	diverged := p.diverged
+	p.lock.Unlock()
+
+	if !accepted || diverged {
+		// A diverged node applies nothing until it fetched the state of the primary
+		return &pb.ReplicateReply{Ok: false, View: view, Seq: n.State.Seq}, nil
+	}
+
+	cmd := &Command{}
+	if err := json.Unmarshal(req.Command, cmd); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid command: %v", err)
+	}
+
+	if cmd.Seq <= n.State.Seq {
+		// Already applied if the primary is retrying. A primary that took over
+		// while behind this node sends other commands at the same seq, this node
+		// then applied commands the group never agreed on
+		if n.applied(cmd) {
+			return &pb.ReplicateReply{Ok: true, View: view, Seq: n.State.Seq}, nil
+		}
+
+		logger.EPrintf("Primary %s sent another command at seq %d than the one applied here, fetching its state\n", req.PrimaryId, cmd.Seq)
+		p.lock.Lock()
+		p.diverged = true
+		p.lock.Unlock()
+
+		return &pb.ReplicateReply{Ok: false, View: view, Seq: n.State.Seq}, nil
+	} else if cmd.Seq != n.State.Seq+1 {
+		return &pb.ReplicateReply{Ok: false, View: view, Seq: n.State.Seq}, nil
+	}
+
+	if _, err := n.execute(cmd); err != nil {
+		return nil, err
+	}
+
+	return &pb.ReplicateReply{Ok: true, View: view, Seq: n.State.Seq}, nil
+}
+
+func (n *Node) Heartbeat(_ context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatReply, error) {
+	if n.passive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "primary-backup replication is not enabled")
+	}
+
+	n.lock.RLock()
+	seq := n.State.Seq
+	n.lock.RUnlock()
+
+	p := n.passive
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.accept(req.View, req.PrimaryId) {
+		// Heartbeats are only sent between pushes, so every backup still in the group is at the same seq
+		if req.Seq > seq && !p.stale {
+			logger.EPrintf("Missed commands from the primary, at seq %d instead of %d\n", seq, req.Seq)
+		}
+		p.stale = req.Seq > seq
+		if req.Seq < seq && !p.diverged {
+			// Only a node that applied commands the primary never had can be ahead of it
+			logger.EPrintf("Ahead of the primary at seq %d instead of %d, fetching its state\n", seq, req.Seq)
+			p.diverged = true
+		}
+	}
+
+	return &pb.HeartbeatReply{View: p.view, Seq: seq}, nil
+}
+
+func (n *Node) GetPrimary(_ context.Context, _ *pb.PrimaryRequest) (*pb.PrimaryReply, error) {
+	if n.passive == nil {
+		return nil, status.Error(codes.FailedPrecondition, "primary-backup replication is not enabled")
+	}
+
+	p := n.passive
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return &pb.PrimaryReply{
+		View:      p.view,
+		PrimaryId: p.primaryID,
+		Address:   p.peers[p.primaryID],
+	}, nil
+}
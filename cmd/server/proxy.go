@@ -0,0 +1,105 @@
+package main
+
+import (
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+)
+
+/*
+Places a bid in an English auction, with proxy bidding. Every bidder may give
+a secret maximum along with their bid, and only the one of the highest bidder
+is kept: any other maximum is below the highest bid, so it cannot matter any
+more. A competing bid is answered right away by raising the highest bid, by
+the minimum increment, as far as the maximum of the highest bidder allows.
+Only the command and the auction decide the outcome, so every replica ends up
+with the same highest bid
+*/
+func (a *Auction) placeProxyBid(cmd *Command) *pb.BidReply {
+	bid := cmd.Bid
+	max := cmd.MaxBid
+	if max < bid {
+		max = bid
+	}
+
+	// Without a proxy the highest bidder only goes as far as their bid
+	leaderMax := a.HighestMax
+	if leaderMax < a.HighestBid {
+		leaderMax = a.HighestBid
+	}
+
+	if len(a.HighestBidder) > 0 && a.HighestBidder == cmd.Bidder {
+		if max <= leaderMax && bid <= a.HighestBid {
+			logger.IPrintf("%s is already the highest bidder in %s and did not raise, rejecting\n", cmd.Bidder, a.ID)
+			return rejectBid(pb.BidReply_FAIL, pb.BidReply_NOT_ABOVE_OWN_BID)
+		}
+
+		logger.IPrintf("%s raised their bid in %s\n", cmd.Bidder, a.ID)
+		if bid > a.HighestBid {
+			a.HighestBid = bid
+		}
+		if max > a.HighestMax {
+			a.HighestMax = max
+		}
+		a.HighestBid = a.raiseToReserve(a.HighestBid, a.HighestMax)
+
+		return &pb.BidReply{
+			Outcome: pb.BidReply_SUCCESS,
+		}
+	}
+
+	if minimum := a.minimumBid(); bid < minimum {
+		logger.IPrintf("New bid is below the minimum in %s. Highest: %d, minimum %d, new %d\n", a.ID, a.HighestBid, minimum, bid)
+		if len(a.HighestBidder) == 0 {
+			return rejectBid(pb.BidReply_FAIL, pb.BidReply_BELOW_STARTING_PRICE)
+		}
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_BELOW_MIN_INCREMENT)
+	}
+
+	if max <= leaderMax && len(a.HighestBidder) > 0 {
+		// The highest bidder keeps the lead, an equal maximum goes to whoever got there first
+		price := max + a.increment(max)
+		if price > leaderMax {
+			price = leaderMax
+		}
+
+		logger.IPrintf("Proxy of %s outbids %s in %s at %d\n", a.HighestBidder, cmd.Bidder, a.ID, price)
+		a.HighestBid = a.raiseToReserve(price, leaderMax)
+
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_OUTBID)
+	}
+
+	price := bid
+	if len(a.HighestBidder) > 0 && leaderMax+a.increment(leaderMax) > price {
+		price = leaderMax + a.increment(leaderMax)
+		if price > max {
+			price = max
+		}
+	}
+
+	logger.IPrintf("Setting new highest value in %s. Old: %d (%s), new %d (%s)\n", a.ID, a.HighestBid, a.HighestBidder, price, cmd.Bidder)
+	a.HighestBid = a.raiseToReserve(price, max)
+	a.HighestBidder = cmd.Bidder
+	a.HighestBidTime = time.Unix(0, cmd.Time)
+	a.HighestMax = max
+
+	return &pb.BidReply{
+		Outcome: pb.BidReply_SUCCESS,
+	}
+}
+
+/*
+Raises the price of the highest bidder to the reserve price if their maximum
+covers it, as they would rather pay the reserve than not win the item
+*/
+func (a *Auction) raiseToReserve(price int64, max int64) int64 {
+	if price >= a.ReservePrice {
+		return price
+	}
+
+	if max >= a.ReservePrice {
+		return a.ReservePrice
+	}
+
+	return price
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/raft"
+	"google.golang.org/grpc"
+)
+
+/*
+Creates the command retracting a bid. The time the load balancer chose is
+used if there is one, so replicas coordinated by quorum agree on whether the
+retract window passed
+*/
+func NewRetractCommand(req *pb.RetractRequest, approved bool) *Command {
+	cmd := NewAdminCommand(opRetract, req.GetAuctionId(), req.GetTime())
+	cmd.Bidder = req.GetBidder()
+	cmd.RequestID = req.GetRequestId()
+	cmd.Note = req.GetReason()
+	cmd.Approved = approved
+
+	return cmd
+}
+
+func (n *Node) RetractBid(ctx context.Context, req *pb.RetractRequest) (*pb.RetractReply, error) {
+	return n.retract(ctx, NewRetractCommand(req, false), func(conn *grpc.ClientConn, ctx context.Context) (*pb.RetractReply, error) {
+		return pb.NewAuctionClient(conn).RetractBid(ctx, req)
+	})
+}
+
+// Retracts a bid on behalf of the bidder, the auctioneer is not held to the retract window
+func (s *adminServer) RetractBid(ctx context.Context, req *pb.RetractRequest) (*pb.RetractReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	return s.n.retract(ctx, NewRetractCommand(req, true), func(conn *grpc.ClientConn, ctx context.Context) (*pb.RetractReply, error) {
+		return pb.NewAuctionAdminClient(conn).RetractBid(withAdminToken(ctx, s.token), req)
+	})
+}
+
+/*
+Submits a retraction, forwarding the call to the leader if this node is a
+Raft follower. The retraction goes through the log like a bid, so every
+replica applies it at the same place among the bids. Replicas that each apply
+bids in the order they arrive in would restore different bids, so they take
+no retractions
+*/
+func (n *Node) retract(ctx context.Context, cmd *Command, forward func(*grpc.ClientConn, context.Context) (*pb.RetractReply, error)) (*pb.RetractReply, error) {
+	if n.unorderedBids {
+		logger.IPrintf("Retraction by %s in %s but bids are not ordered, rejecting\n", cmd.Bidder, cmd.AuctionID)
+		return &pb.RetractReply{
+			Outcome: pb.RetractReply_NOT_SUPPORTED,
+		}, nil
+	}
+
+	reply, err := n.submit(ctx, cmd)
+	if err == raft.ErrNotLeader {
+		var conn *grpc.ClientConn
+		conn, ctx, err = n.dialLeader(ctx)
+		if err != nil {
+			return &pb.RetractReply{
+				Outcome: pb.RetractReply_UNAVAILABLE,
+			}, nil
+		}
+		defer conn.Close()
+
+		return forward(conn, ctx)
+	} else if err == errNotPrimary {
+		return nil, err
+	} else if err != nil && n.raft != nil {
+		logger.EPrintf("Retraction was not committed: %v\n", err)
+		return &pb.RetractReply{
+			Outcome: pb.RetractReply_UNAVAILABLE,
+		}, nil
+	} else if err != nil {
+		return &pb.RetractReply{
+			Outcome: pb.RetractReply_EXCEPTION,
+		}, nil
+	}
+
+	return reply.(*pb.RetractReply), nil
+}
+
+/*
+Takes back an accepted bid. A bidder may only retract their bid within the
+retract window of the auction, the auctioneer at any time, and only while
+the auction is open or paused. The bid stays in the history marked as
+retracted, followed by a record of the retraction
+*/
+func (s *State) applyRetract(cmd *Command) *pb.RetractReply {
+	auction, ok := s.Auctions[cmd.AuctionID]
+	if !ok {
+		logger.IPrintf("Retraction in unknown auction %s, rejecting\n", cmd.AuctionID)
+		return &pb.RetractReply{
+			Outcome: pb.RetractReply_UNKNOWN_AUCTION,
+		}
+	}
+
+	at := time.Unix(0, cmd.Time)
+	reply := &pb.RetractReply{
+		Outcome: auction.retract(cmd, at),
+	}
+	reply.Result = auction.Result(at)
+
+	return reply
+}
+
+func (a *Auction) retract(cmd *Command, at time.Time) pb.RetractReply_Outcome {
+	if a.State != pb.AuctionState_OPEN && a.State != pb.AuctionState_PAUSED {
+		logger.IPrintf("Retraction by %s in %s while it is %s, rejecting\n", cmd.Bidder, a.ID, a.State)
+		return pb.RetractReply_NOT_OPEN
+	}
+
+	bid := a.findBid(cmd.Bidder, cmd.RequestID)
+	if bid == nil {
+		logger.IPrintf("%s has no accepted bid %q in %s to retract\n", cmd.Bidder, cmd.RequestID, a.ID)
+		return pb.RetractReply_UNKNOWN_BID
+	}
+
+	if bid.Retracted {
+		return pb.RetractReply_ALREADY_RETRACTED
+	}
+
+	if !cmd.Approved && at.Sub(bid.Time) > a.RetractWindow {
+		logger.IPrintf("Retract window of bid %d by %s in %s passed, rejecting\n", bid.ID, cmd.Bidder, a.ID)
+		return pb.RetractReply_WINDOW_PASSED
+	}
+
+	logger.IPrintf("Retracting bid %d of %d by %s in %s: %q\n", bid.ID, bid.Amount, cmd.Bidder, a.ID, cmd.Note)
+	bid.Retracted = true
+
+	if a.Type == pb.AuctionType_VICKREY {
+		a.restoreSealedBid(cmd.Bidder)
+	} else if a.HighestBidder == cmd.Bidder {
+		a.restoreHighestBid()
+	}
+
+	record := a.record(cmd.Bidder, bid.Amount, at, &pb.BidReply{Outcome: pb.BidReply_SUCCESS}, false)
+	record.Retracts = bid.ID
+	record.Note = cmd.Note
+	record.Approved = cmd.Approved
+
+	return pb.RetractReply_SUCCESS
+}
+
+/*
+Finds the accepted bid of the bidder with the request id, or their latest
+accepted bid without one. Retracted bids are found too, so retracting twice
+can be told apart from a bid that never was
+*/
+func (a *Auction) findBid(bidder string, requestID string) *BidRecord {
+	if len(bidder) == 0 {
+		return nil
+	}
+
+	for i := len(a.Bids) - 1; i >= 0; i-- {
+		record := a.Bids[i]
+		if record.Retracts > 0 || record.Bidder != bidder || record.Outcome != pb.BidReply_SUCCESS {
+			continue
+		}
+
+		if len(requestID) == 0 || record.RequestID == requestID {
+			return record
+		}
+	}
+
+	return nil
+}
+
+/*
+Falls back to the bid that led before the retracted one, the latest accepted
+bid still standing. It leads at the highest price it reached before the next
+bid was accepted, which its proxy may have raised. Without one the auction
+has no bids again
+*/
+func (a *Auction) restoreHighestBid() {
+	var previous *BidRecord
+	price := int64(0)
+	for i := len(a.Bids) - 1; i >= 0; i-- {
+		record := a.Bids[i]
+		if record.Retracts > 0 || record.Outcome != pb.BidReply_SUCCESS {
+			if record.Price > price {
+				price = record.Price
+			}
+		} else if record.Retracted {
+			price = 0
+		} else {
+			previous = record
+			break
+		}
+	}
+
+	if previous == nil {
+		logger.IPrintf("No bid left in %s after the retraction\n", a.ID)
+		a.HighestBid = 0
+		a.HighestBidder = ""
+		a.HighestBidTime = time.Time{}
+		a.HighestMax = 0
+		return
+	}
+
+	if previous.Price > price {
+		price = previous.Price
+	}
+	if price <= 0 {
+		price = previous.Amount
+	}
+
+	logger.IPrintf("Highest bid in %s falls back to %d by %s\n", a.ID, price, previous.Bidder)
+	a.HighestBid = price
+	a.HighestBidder = previous.Bidder
+	a.HighestBidTime = previous.Time
+	a.HighestMax = previous.Max
+}
+
+// Falls back to the best sealed bid of the bidder still standing, if any
+func (a *Auction) restoreSealedBid(bidder string) {
+	delete(a.SealedBids, bidder)
+	for _, record := range a.Bids {
+		if record.Retracts > 0 || record.Retracted || record.Bidder != bidder || record.Outcome != pb.BidReply_SUCCESS {
+			continue
+		}
+
+		if best, ok := a.SealedBids[bidder]; !ok || record.Amount > best.Amount {
+			a.SealedBids[bidder] = &SealedBid{
+				Amount: record.Amount,
+				Time:   record.Time,
+			}
+		}
+	}
+}
+
+/*
+Whether the bidder retracted a bid of the amount here and has no other bid
+of it standing, so a read repair from a replica that missed the retraction
+must not bring it back
+*/
+func (a *Auction) retracted(bidder string, amount int64) bool {
+	retracted := false
+	for _, record := range a.Bids {
+		if record.Retracts > 0 || record.Bidder != bidder || record.Outcome != pb.BidReply_SUCCESS {
+			continue
+		}
+		if record.Amount != amount && record.Price != amount {
+			continue
+		}
+
+		if !record.Retracted {
+			return false
+		}
+		retracted = true
+	}
+
+	return retracted
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/money"
+)
+
+// Minimum increment of auctions created without one
+const defaultMinIncrement = 1
+
+// IncrementTier is the minimum increment while the highest bid is below a limit
+type IncrementTier struct {
+	Below     int64
+	Increment int64
+}
+
+// Sets the bidding rules of a new auction from the command creating it
+func (a *Auction) setRules(cmd *Command) {
+	a.MinIncrement = cmd.MinIncrement
+	a.ReservePrice = cmd.ReservePrice
+	a.StartingPrice = cmd.StartingPrice
+	a.Increments = cmd.Increments
+	a.SoftClose = cmd.SoftClose
+	a.RetractWindow = time.Duration(cmd.RetractWindow) * time.Millisecond
+	a.Currency = cmd.Currency
+	a.Seller = cmd.Seller
+	a.FeeBps = cmd.FeeBps
+}
+
+// Copies the valid tiers, ordered by their limit
+func NewIncrementTiers(tiers []*pb.IncrementTier) []IncrementTier {
+	result := make([]IncrementTier, 0, len(tiers))
+	for _, tier := range tiers {
+		below := money.Amount(tier.GetBelowAmount(), tier.GetBelow())
+		increment := money.Amount(tier.GetIncrementAmount(), tier.GetIncrement())
+		if below > 0 && increment > 0 {
+			result = append(result, IncrementTier{
+				Below:     below,
+				Increment: increment,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Below < result[j].Below
+	})
+
+	return result
+}
+
+func incrementTiersInfo(tiers []IncrementTier, currency string) []*pb.IncrementTier {
+	result := make([]*pb.IncrementTier, 0, len(tiers))
+	for _, tier := range tiers {
+		result = append(result, &pb.IncrementTier{
+			Below:           money.Legacy(tier.Below),
+			Increment:       money.Legacy(tier.Increment),
+			BelowAmount:     money.New(tier.Below, currency),
+			IncrementAmount: money.New(tier.Increment, currency),
+		})
+	}
+
+	return result
+}
+
+/*
+Returns how much a bid must be above the given price. The tier with the
+lowest limit above the price applies, the flat minimum increment above the
+last tier
+*/
+func (a *Auction) increment(price int64) int64 {
+	for _, tier := range a.Increments {
+		if price < tier.Below {
+			return tier.Increment
+		}
+	}
+
+	if a.MinIncrement <= 0 {
+		return defaultMinIncrement
+	}
+
+	return a.MinIncrement
+}
+
+// Returns the lowest bid accepted from a bidder who is not the highest bidder
+func (a *Auction) minimumBid() int64 {
+	if len(a.HighestBidder) > 0 {
+		return a.HighestBid + a.increment(a.HighestBid)
+	}
+
+	if a.StartingPrice > a.HighestBid {
+		return a.StartingPrice
+	}
+
+	return a.HighestBid + 1
+}
+
+// Whether the item is sold, an auction without a reserve price only needs a bid
+func (a *Auction) reserveMet() bool {
+	return len(a.HighestBidder) > 0 && a.HighestBid >= a.ReservePrice
+}
+
+func rejectBid(outcome pb.BidReply_Outcome, reason pb.BidReply_Reason) *pb.BidReply {
+	return &pb.BidReply{
+		Outcome: outcome,
+		Reason:  reason,
+	}
+}
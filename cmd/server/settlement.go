@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/ledger"
+	"github.com/ap/DMP3/internal/money"
+	"github.com/ap/DMP3/internal/raft"
+)
+
+const (
+	// Seller of auctions created without one
+	houseSeller = "house"
+	// Ledger account the platform fees are credited to
+	feeAccount = "platform:fees"
+	// Basis points in a whole
+	wholeBps = 10000
+)
+
+/*
+LedgerEntry is a line in the settlement ledger. Entries are only ever
+appended, and each one hashes the one before it, so the ledger can be
+verified from the first entry to the last
+*/
+type LedgerEntry struct {
+	Seq       uint64
+	InvoiceID string
+	AuctionID string
+	Account   string
+	Side      pb.LedgerEntry_Side
+	Amount    int64
+	Currency  string
+	Time      time.Time
+	Memo      string `json:",omitempty"`
+	PrevHash  string `json:",omitempty"`
+	Hash      string
+}
+
+// Invoice is what an auction was settled for, written once when it is settled
+type Invoice struct {
+	ID        string
+	AuctionID string
+	Item      string
+	Sold      bool
+	Buyer     string `json:",omitempty"`
+	Seller    string
+	Price     int64 `json:",omitempty"`
+	Fee       int64 `json:",omitempty"`
+	FeeBps    int64 `json:",omitempty"`
+	Currency  string
+	SettledAt time.Time
+	// Sequence numbers of the ledger entries it was booked with
+	Entries []uint64 `json:",omitempty"`
+}
+
+func (e *LedgerEntry) Info() *pb.LedgerEntry {
+	return &pb.LedgerEntry{
+		Seq:       e.Seq,
+		InvoiceId: e.InvoiceID,
+		AuctionId: e.AuctionID,
+		Account:   e.Account,
+		Side:      e.Side,
+		Amount:    money.New(e.Amount, e.Currency),
+		Time:      e.Time.UnixMilli(),
+		Memo:      e.Memo,
+		PrevHash:  e.PrevHash,
+		Hash:      e.Hash,
+	}
+}
+
+func (i *Invoice) Info() *pb.Invoice {
+	return &pb.Invoice{
+		InvoiceId: i.ID,
+		AuctionId: i.AuctionID,
+		Item:      i.Item,
+		Sold:      i.Sold,
+		Buyer:     i.Buyer,
+		Seller:    i.Seller,
+		Price:     money.New(i.Price, i.Currency),
+		Fee:       money.New(i.Fee, i.Currency),
+		FeeBps:    int32(i.FeeBps),
+		Proceeds:  money.New(i.Price-i.Fee, i.Currency),
+		SettledAt: i.SettledAt.UnixMilli(),
+		Entries:   i.Entries,
+	}
+}
+
+// Who is paid when the item is sold
+func (a *Auction) seller() string {
+	if len(a.Seller) == 0 {
+		return houseSeller
+	}
+
+	return a.Seller
+}
+
+// The platform fee on the price, rounded down, without overflowing on large prices
+func fee(price int64, feeBps int64) int64 {
+	return price/wholeBps*feeBps + price%wholeBps*feeBps/wholeBps
+}
+
+/*
+Books an entry at the end of the ledger, chained to the one before it. The
+caller keeps the ledger balanced by booking every side of an invoice
+*/
+func (s *State) book(invoice *Invoice, account string, side pb.LedgerEntry_Side, amount int64, memo string) {
+	entry := &LedgerEntry{
+		Seq:       uint64(len(s.Ledger)) + 1,
+		InvoiceID: invoice.ID,
+		AuctionID: invoice.AuctionID,
+		Account:   account,
+		Side:      side,
+		Amount:    amount,
+		Currency:  invoice.Currency,
+		Time:      invoice.SettledAt,
+		Memo:      memo,
+	}
+	if len(s.Ledger) > 0 {
+		entry.PrevHash = s.Ledger[len(s.Ledger)-1].Hash
+	}
+	entry.Hash = ledger.Hash(entry.Info())
+
+	s.Ledger = append(s.Ledger, entry)
+	invoice.Entries = append(invoice.Entries, entry.Seq)
+}
+
+/*
+Settles the auction once it moves to settled. A sold item is booked as a
+debit of the buyer against credits of the seller and the platform fee, and
+the accounts of the buyer and seller are charged and paid if they have one.
+An unsold item still gets an invoice, without entries
+*/
+func (s *State) settle(auction *Auction, at time.Time) {
+	if s.Invoices == nil {
+		s.Invoices = make(map[string]*Invoice)
+	}
+	if _, ok := s.Invoices[auction.ID]; ok {
+		return
+	}
+
+	invoice := &Invoice{
+		ID:        "INV-" + auction.ID,
+		AuctionID: auction.ID,
+		Item:      auction.Item,
+		Seller:    auction.seller(),
+		FeeBps:    auction.FeeBps,
+		Currency:  auction.currency(),
+		SettledAt: at,
+	}
+	s.Invoices[auction.ID] = invoice
+
+	if !auction.reserveMet() {
+		logger.IPrintf("Invoiced %s as not sold\n", auction.ID)
+		return
+	}
+
+	invoice.Sold = true
+	invoice.Buyer = auction.HighestBidder
+	invoice.Price = auction.price()
+	invoice.Fee = fee(invoice.Price, invoice.FeeBps)
+	proceeds := invoice.Price - invoice.Fee
+
+	s.book(invoice, "buyer:"+invoice.Buyer, pb.LedgerEntry_DEBIT, invoice.Price, fmt.Sprintf("Purchase of %q", invoice.Item))
+	s.book(invoice, "seller:"+invoice.Seller, pb.LedgerEntry_CREDIT, proceeds, fmt.Sprintf("Sale of %q", invoice.Item))
+	if invoice.Fee > 0 {
+		s.book(invoice, feeAccount, pb.LedgerEntry_CREDIT, invoice.Fee, fmt.Sprintf("Fee of %d bps", invoice.FeeBps))
+	}
+
+	logger.IPrintf("Invoiced %s: %s pays %s, %s gets %s\n", auction.ID, invoice.Buyer, money.Format(invoice.Price, invoice.Currency), invoice.Seller, money.Format(proceeds, invoice.Currency))
+
+	s.chargeWinner(auction)
+	if account, ok := s.Accounts[invoice.Seller]; ok && account.Currency == invoice.Currency {
+		logger.IPrintf("Paying %s %s for %s\n", invoice.Seller, money.Format(proceeds, invoice.Currency), auction.ID)
+		account.Balance += proceeds
+	}
+}
+
+/*
+Checks the whole ledger is chained and every invoice balanced. The entries
+verified by an earlier check are not verified again, only the head they end
+with is hashed again to check it did not change
+*/
+func (s *State) verifyLedger() error {
+	s.verifierLock.Lock()
+	defer s.verifierLock.Unlock()
+
+	if s.verifier == nil {
+		s.verifier = &ledger.Verifier{}
+	}
+
+	if count := s.verifier.Count(); count > 0 && ledger.Hash(s.Ledger[count-1].Info()) != s.verifier.Head() {
+		return fmt.Errorf("entry %d was changed after it was verified", count)
+	}
+
+	for _, entry := range s.Ledger[s.verifier.Count():] {
+		if err := s.verifier.Add(entry.Info()); err != nil {
+			return err
+		}
+	}
+
+	return s.verifier.Balanced()
+}
+
+// Shows what the buyer paid and the seller got, so only holders of the admin token may read it
+func (s *adminServer) GetSettlement(ctx context.Context, req *pb.SettlementRequest) (*pb.SettlementReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	n := s.n
+
+	// Only the leader is guaranteed to have applied every acknowledged change
+	if n.raft != nil && n.raft.Role() != raft.Leader {
+		conn, ctx, err := n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return pb.NewAuctionAdminClient(conn).GetSettlement(withAdminToken(ctx, s.token), req)
+	}
+	if err := n.readBarrier(ctx); err != nil {
+		return nil, err
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	auctionID := auctionIDOrDefault(req.GetAuctionId())
+	if _, ok := n.State.Auctions[auctionID]; !ok {
+		return &pb.SettlementReply{
+			Outcome: pb.SettlementReply_UNKNOWN_AUCTION,
+		}, nil
+	}
+
+	invoice, ok := n.State.Invoices[auctionID]
+	if !ok {
+		return &pb.SettlementReply{
+			Outcome: pb.SettlementReply_NOT_SETTLED,
+		}, nil
+	}
+
+	reply := &pb.SettlementReply{
+		Outcome: pb.SettlementReply_SUCCESS,
+		Invoice: invoice.Info(),
+	}
+	for _, seq := range invoice.Entries {
+		reply.Entries = append(reply.Entries, n.State.Ledger[seq-1].Info())
+	}
+
+	return reply, nil
+}
+
+/*
+Lists the ledger from the given entry on, a page at a time. The ledger is
+verified up to its head on every call, so a reader can tell the page comes
+from an intact chain
+*/
+func (s *adminServer) ListLedgerEntries(ctx context.Context, req *pb.ListLedgerEntriesRequest) (*pb.ListLedgerEntriesReply, error) {
+	if err := authorizeAdmin(ctx, s.token); err != nil {
+		return nil, err
+	}
+
+	n := s.n
+
+	if n.raft != nil && n.raft.Role() != raft.Leader {
+		conn, ctx, err := n.dialLeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		return pb.NewAuctionAdminClient(conn).ListLedgerEntries(withAdminToken(ctx, s.token), req)
+	}
+	if err := n.readBarrier(ctx); err != nil {
+		return nil, err
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = 100
+	} else if pageSize > 1000 {
+		pageSize = 1000
+	}
+
+	reply := &pb.ListLedgerEntriesReply{}
+	if err := n.State.verifyLedger(); err != nil {
+		logger.EPrintf("Ledger does not verify: %v\n", err)
+	} else {
+		reply.Verified = true
+	}
+
+	entries := n.State.Ledger
+	if len(entries) > 0 {
+		reply.HeadHash = entries[len(entries)-1].Hash
+	}
+
+	from := req.GetFromSeq()
+	if from < 1 {
+		from = 1
+	}
+	for seq := from; seq <= uint64(len(entries)); seq++ {
+		entry := entries[seq-1]
+		if len(req.GetAuctionId()) > 0 && entry.AuctionID != req.GetAuctionId() {
+			continue
+		}
+
+		if len(reply.Entries) == pageSize {
+			reply.NextSeq = seq
+			break
+		}
+		reply.Entries = append(reply.Entries, entry.Info())
+	}
+
+	return reply, nil
+}
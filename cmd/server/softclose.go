@@ -0,0 +1,80 @@
+package main
+
+import (
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+)
+
+// SoftClose is how an auction is extended when a bid is accepted shortly before it ends
+type SoftClose struct {
+	Window    time.Duration
+	Extension time.Duration
+	// Zero if the auction may be extended forever
+	HardClose time.Time
+}
+
+// Returns nil unless both the window and the extension are set
+func NewSoftClose(softClose *pb.SoftClose) *SoftClose {
+	if softClose.GetWindowMs() <= 0 || softClose.GetExtensionMs() <= 0 {
+		return nil
+	}
+
+	return &SoftClose{
+		Window:    time.Duration(softClose.GetWindowMs()) * time.Millisecond,
+		Extension: time.Duration(softClose.GetExtensionMs()) * time.Millisecond,
+		HardClose: timeOrZero(softClose.GetHardCloseTime()),
+	}
+}
+
+func (s *SoftClose) Info() *pb.SoftClose {
+	if s == nil {
+		return nil
+	}
+
+	return &pb.SoftClose{
+		WindowMs:      s.Window.Milliseconds(),
+		ExtensionMs:   s.Extension.Milliseconds(),
+		HardCloseTime: unixMilliOrZero(s.HardClose),
+	}
+}
+
+/*
+Extends the auction if a bid accepted at the given time is within the final
+window. The end time moves by the extension, but never past the hard close
+*/
+func (a *Auction) extend(bidTime time.Time) {
+	if a.SoftClose == nil || a.EndTime.IsZero() || a.EndTime.Sub(bidTime) > a.SoftClose.Window {
+		return
+	}
+
+	a.moveEndTime(a.EndTime.Add(a.SoftClose.Extension))
+}
+
+/*
+Adopts a later end time another replica reached through extensions this one
+missed. Only auctions that can be extended at all are moved
+*/
+func (a *Auction) adoptEndTime(endTime time.Time) bool {
+	if a.SoftClose == nil || a.State != pb.AuctionState_OPEN || !endTime.After(a.EndTime) {
+		return false
+	}
+
+	return a.moveEndTime(endTime)
+}
+
+func (a *Auction) moveEndTime(endTime time.Time) bool {
+	if hardClose := a.SoftClose.HardClose; !hardClose.IsZero() && endTime.After(hardClose) {
+		endTime = hardClose
+	}
+
+	if !endTime.After(a.EndTime) {
+		return false
+	}
+
+	logger.IPrintf("Extending %s from %s to %s\n", a.ID, a.EndTime, endTime)
+	a.EndTime = endTime
+	a.Extensions++
+
+	return true
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/ledger"
+	"github.com/ap/DMP3/internal/money"
+)
+
+// Operations a command can carry
+const (
+	opCreateAuction = "create-auction"
+	opBid           = "bid"
+	opRepair        = "repair"
+	opTransition    = "transition"
+	opPause         = "pause"
+	opResume        = "resume"
+	opClose         = "close"
+	opCancel        = "cancel"
+	opExtend        = "extend"
+	opRetract       = "retract"
+	opOpenAccount   = "open-account"
+	opDeposit       = "deposit"
+	opWithdraw      = "withdraw"
+	opCreditLimit   = "credit-limit"
+)
+
+// Command is a single change to the node state. Commands are what gets
+// written to the bid log, so applying one may only depend on the command and
+// the state it is applied to, never on the clock or anything else local.
+type Command struct {
+	Seq           uint64          `json:"seq"`
+	Op            string          `json:"op"`
+	Time          int64           `json:"time"` // Unix time in nanoseconds the command was issued
+	AuctionID     string          `json:"auctionId"`
+	Item          string          `json:"item,omitempty"`
+	Type          pb.AuctionType  `json:"type,omitempty"`
+	Dutch         *DutchSchedule  `json:"dutch,omitempty"`
+	Currency      string          `json:"currency,omitempty"`
+	MinIncrement  int64           `json:"minIncrement,omitempty"`
+	ReservePrice  int64           `json:"reservePrice,omitempty"`
+	StartingPrice int64           `json:"startingPrice,omitempty"`
+	Increments    []IncrementTier `json:"increments,omitempty"`
+	SoftClose     *SoftClose      `json:"softClose,omitempty"`
+	RetractWindow int64           `json:"retractWindow,omitempty"` // Milliseconds
+	Seller        string          `json:"seller,omitempty"`
+	FeeBps        int64           `json:"feeBps,omitempty"`
+	StartTime     int64           `json:"startTime,omitempty"` // Unix time in milliseconds
+	EndTime       int64           `json:"endTime,omitempty"`   // Unix time in milliseconds
+	Bid           int64           `json:"bid,omitempty"`
+	Bidder        string          `json:"bidder,omitempty"`
+	MaxBid        int64           `json:"maxBid,omitempty"`
+	MaxCurrency   string          `json:"maxCurrency,omitempty"`
+	BidTime       int64           `json:"bidTime,omitempty"`     // Unix time in milliseconds of a repaired bid
+	BidCurrency   string          `json:"bidCurrency,omitempty"` // Currency of a repaired bid, the auction currency if empty
+	RequestID     string          `json:"requestId,omitempty"`
+	// Lifecycle state a transition moves the auction to
+	State pb.AuctionState `json:"state,omitempty"`
+	// Reason of a retraction, and whether the auctioneer made it
+	Note     string `json:"note,omitempty"`
+	Approved bool   `json:"approved,omitempty"`
+	// Amount an account operation moves or sets, in the currency of the command
+	Amount int64 `json:"amount,omitempty"`
+	// Whether bidders need an account to bid
+	RequireAccount bool `json:"requireAccount,omitempty"`
+}
+
+// State is everything a node needs to serve requests and what gets written to snapshots
+type State struct {
+	Seq uint64
+	// Number of commands applied that this node did not issue itself. Replicas
+	// coordinated by the load balancer each issue their own transitions, so
+	// only this count lines up between them
+	Writes   uint64 `json:",omitempty"`
+	Auctions map[string]*Auction
+	Dedup    *DedupTable
+	Accounts map[string]*Account `json:",omitempty"`
+	// Append-only settlement ledger, and the invoice of every settled auction
+	Ledger   []*LedgerEntry      `json:",omitempty"`
+	Invoices map[string]*Invoice `json:",omitempty"`
+	// Events of the commands applied since they were last handed to the watchers
+	events []*pb.AuctionEvent
+	// How far the ledger was verified, checked by readers holding the read lock
+	verifier     *ledger.Verifier
+	verifierLock sync.Mutex
+}
+
+func NewState() *State {
+	// The default auction is created by the lifecycle loop or the load balancer, so it gets a start and end time
+	return &State{
+		Seq:      0,
+		Auctions: make(map[string]*Auction),
+		Dedup:    NewDedupTable(),
+		Accounts: make(map[string]*Account),
+		Invoices: make(map[string]*Invoice),
+	}
+}
+
+/*
+Creates the command for a bid. Old clients only send the int32 amounts,
+those are in the currency of the auction
+*/
+func NewBidCommand(req *pb.BidRequest) *Command {
+	bid, currency := money.OrLegacy(req.GetBidAmount(), req.GetBid())
+	maxBid, maxCurrency := money.OrLegacy(req.GetMaxBidAmount(), req.GetMaxBid())
+
+	// The time the load balancer stamped, so every replica applies the bid at the same time
+	now := time.Now()
+	if req.GetTime() > 0 {
+		now = time.UnixMilli(req.GetTime())
+	}
+
+	return &Command{
+		Op:          opBid,
+		Time:        now.UnixNano(),
+		AuctionID:   auctionIDOrDefault(req.GetAuctionId()),
+		Currency:    currency,
+		Bid:         bid,
+		Bidder:      req.GetBidder(),
+		MaxBid:      maxBid,
+		MaxCurrency: maxCurrency,
+		RequestID:   req.GetRequestId(),
+	}
+}
+
+// The currency an auction is created in, the default currency if none is given
+func currencyOrDefault(currency string) string {
+	if len(currency) == 0 {
+		return money.DefaultCurrency
+	}
+
+	return currency
+}
+
+/*
+Creates the command for a new auction. The times are fixed here rather than
+when the command is applied: the auction opens at the requested start time or
+right away, and ends at the requested end time or after its duration, falling
+back to defaultDuration. Without a fee the platform takes defaultFeeBps
+*/
+func NewCreateAuctionCommand(req *pb.CreateAuctionRequest, defaultDuration time.Duration, defaultFeeBps int64) *Command {
+	now := time.Now()
+
+	startTime := req.GetStartTime()
+	if startTime <= 0 {
+		startTime = now.UnixMilli()
+	}
+
+	endTime := req.GetEndTime()
+	if endTime <= 0 {
+		duration := defaultDuration
+		if req.GetDurationSeconds() > 0 {
+			duration = time.Duration(req.GetDurationSeconds()) * time.Second
+		}
+		endTime = time.UnixMilli(startTime).Add(duration).UnixMilli()
+	}
+
+	feeBps := int64(req.GetFeeBps())
+	if feeBps == 0 {
+		feeBps = defaultFeeBps
+	}
+
+	return &Command{
+		Op:            opCreateAuction,
+		Time:          now.UnixNano(),
+		AuctionID:     req.GetAuctionId(),
+		Item:          req.GetItem(),
+		Type:          req.GetType(),
+		Dutch:         NewDutchSchedule(req.GetDutch()),
+		Currency:      currencyOrDefault(req.GetCurrency()),
+		MinIncrement:  money.Amount(req.GetMinIncrementAmount(), req.GetMinIncrement()),
+		ReservePrice:  money.Amount(req.GetReservePriceAmount(), req.GetReservePrice()),
+		StartingPrice: money.Amount(req.GetStartingPriceAmount(), req.GetStartingPrice()),
+		Increments:    NewIncrementTiers(req.GetIncrements()),
+		SoftClose:     NewSoftClose(req.GetSoftClose()),
+		RetractWindow: req.GetRetractWindowMs(),
+		Seller:        req.GetSeller(),
+		FeeBps:        feeBps,
+		StartTime:     startTime,
+		EndTime:       endTime,
+	}
+}
+
+/*
+Creates the command for a repair. The currency of the repaired bid is kept
+apart from the currency of the auction, so a bid in another currency is not
+taken for one in the currency of the auction
+*/
+func NewRepairCommand(req *pb.RepairRequest) *Command {
+	bid, bidCurrency := money.OrLegacy(req.GetResult().GetResultAmount(), req.GetResult().GetResult())
+
+	return &Command{
+		Op:            opRepair,
+		Time:          time.Now().UnixNano(),
+		AuctionID:     req.GetAuction().GetAuctionId(),
+		Item:          req.GetAuction().GetItem(),
+		Type:          req.GetAuction().GetType(),
+		Dutch:         NewDutchSchedule(req.GetAuction().GetDutch()),
+		Currency:      currencyOrDefault(req.GetAuction().GetCurrency()),
+		MinIncrement:  money.Amount(req.GetAuction().GetMinIncrementAmount(), req.GetAuction().GetMinIncrement()),
+		ReservePrice:  money.Amount(req.GetAuction().GetReservePriceAmount(), req.GetAuction().GetReservePrice()),
+		StartingPrice: money.Amount(req.GetAuction().GetStartingPriceAmount(), req.GetAuction().GetStartingPrice()),
+		Increments:    NewIncrementTiers(req.GetAuction().GetIncrements()),
+		SoftClose:     NewSoftClose(req.GetAuction().GetSoftClose()),
+		RetractWindow: req.GetAuction().GetRetractWindowMs(),
+		Seller:        req.GetAuction().GetSeller(),
+		FeeBps:        int64(req.GetAuction().GetFeeBps()),
+		StartTime:     req.GetAuction().GetStartTime(),
+		EndTime:       req.GetAuction().GetEndTime(),
+		Bid:           bid,
+		Bidder:        req.GetResult().GetWinner(),
+		BidTime:       req.GetResult().GetBidTime(),
+		BidCurrency:   bidCurrency,
+	}
+}
+
+/*
+Applies the command and returns the reply for the request that issued it.
+The command must carry the next sequence number
+*/
+func (s *State) Apply(cmd *Command) interface{} {
+	s.Seq = cmd.Seq
+	if cmd.Op != opTransition {
+		s.Writes++
+	}
+
+	before := s.view(cmd.AuctionID)
+	reply := s.apply(cmd)
+	s.syncHolds(cmd.AuctionID)
+	s.emitEvents(cmd, before)
+
+	return reply
+}
+
+func (s *State) apply(cmd *Command) interface{} {
+	// Time driven transitions happen before the command, so a bid placed after the end time is never accepted
+	if auction, ok := s.Auctions[cmd.AuctionID]; ok {
+		auction.advance(time.Unix(0, cmd.Time))
+	}
+
+	switch cmd.Op {
+	case opBid:
+		return s.applyDedupedBid(cmd)
+	case opCreateAuction:
+		return s.applyCreateAuction(cmd)
+	case opRepair:
+		return s.applyRepair(cmd)
+	case opTransition:
+		return s.applyTransition(cmd)
+	case opPause, opResume, opClose, opCancel, opExtend:
+		return s.applyAdmin(cmd)
+	case opRetract:
+		return s.applyRetract(cmd)
+	case opOpenAccount, opDeposit, opWithdraw, opCreditLimit:
+		return s.applyAccount(cmd)
+	default:
+		logger.EPrintf("Unknown command %q at seq %d, skipping\n", cmd.Op, cmd.Seq)
+		return nil
+	}
+}
+
+func (s *State) applyBid(cmd *Command) *pb.BidReply {
+	auction, ok := s.Auctions[cmd.AuctionID]
+	if !ok {
+		logger.IPrintf("Bid request for unknown auction %s, rejecting\n", cmd.AuctionID)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_UNKNOWN_AUCTION)
+	}
+
+	reply := s.checkFunds(cmd, auction)
+	if reply == nil {
+		reply = auction.placeBid(cmd)
+	}
+	record := auction.record(cmd.Bidder, cmd.Bid, time.Unix(0, cmd.Time), reply, false)
+	record.Currency = cmd.Currency
+	record.RequestID = cmd.RequestID
+	record.Max = cmd.MaxBid
+
+	return reply
+}
+
+func (s *State) applyCreateAuction(cmd *Command) *pb.CreateAuctionReply {
+	if len(cmd.AuctionID) == 0 {
+		logger.IPrintf("Create auction request is missing an auction id, rejecting\n")
+		return &pb.CreateAuctionReply{
+			Outcome: pb.CreateAuctionReply_EXCEPTION,
+		}
+	}
+
+	if _, ok := s.Auctions[cmd.AuctionID]; ok {
+		logger.IPrintf("Auction %s already exists\n", cmd.AuctionID)
+		return &pb.CreateAuctionReply{
+			Outcome: pb.CreateAuctionReply_EXISTS,
+		}
+	}
+
+	if !money.ValidCurrency(cmd.Currency) {
+		logger.IPrintf("Create auction request for %s has an invalid currency %q, rejecting\n", cmd.AuctionID, cmd.Currency)
+		return &pb.CreateAuctionReply{
+			Outcome: pb.CreateAuctionReply_EXCEPTION,
+		}
+	}
+
+	if cmd.FeeBps < 0 || cmd.FeeBps > wholeBps {
+		logger.IPrintf("Create auction request for %s has a fee of %d bps, rejecting\n", cmd.AuctionID, cmd.FeeBps)
+		return &pb.CreateAuctionReply{
+			Outcome: pb.CreateAuctionReply_EXCEPTION,
+		}
+	}
+
+	if cmd.Type == pb.AuctionType_DUTCH && !cmd.Dutch.Valid() {
+		logger.IPrintf("Create auction request for %s is missing a valid Dutch schedule, rejecting\n", cmd.AuctionID)
+		return &pb.CreateAuctionReply{
+			Outcome: pb.CreateAuctionReply_EXCEPTION,
+		}
+	}
+
+	auction := NewAuction(cmd.AuctionID, cmd.Item, cmd.Type, cmd.Dutch, timeOrZero(cmd.StartTime), timeOrZero(cmd.EndTime))
+	auction.setRules(cmd)
+	logger.IPrintf("Creating %s auction %s for %q from %s to %s\n", cmd.Type, cmd.AuctionID, cmd.Item, auction.StartTime, auction.EndTime)
+	auction.advance(time.Unix(0, cmd.Time))
+	s.Auctions[cmd.AuctionID] = auction
+
+	return &pb.CreateAuctionReply{
+		Outcome: pb.CreateAuctionReply_SUCCESS,
+	}
+}
+
+/*
+Overwrites the highest bid with one the load balancer saw on another replica,
+unless this replica already has it or a higher one, and adopts the end time
+if it was extended further there. A missing auction is only created if the
+load balancer knows when it ends, and the result can no longer be repaired
+once the auction is closed
+*/
+func (s *State) applyRepair(cmd *Command) *pb.RepairReply {
+	if len(cmd.AuctionID) == 0 || len(cmd.Bidder) == 0 {
+		return &pb.RepairReply{
+			Repaired: false,
+		}
+	}
+
+	auction, ok := s.Auctions[cmd.AuctionID]
+	if !ok && cmd.EndTime > 0 && (cmd.Type != pb.AuctionType_DUTCH || cmd.Dutch.Valid()) {
+		logger.IPrintf("Repair creates missing auction %s\n", cmd.AuctionID)
+		auction = NewAuction(cmd.AuctionID, cmd.Item, cmd.Type, cmd.Dutch, timeOrZero(cmd.StartTime), timeOrZero(cmd.EndTime))
+		auction.setRules(cmd)
+		auction.advance(time.Unix(0, cmd.Time))
+		s.Auctions[cmd.AuctionID] = auction
+	} else if !ok {
+		return &pb.RepairReply{
+			Repaired: false,
+		}
+	}
+
+	if auction.State != pb.AuctionState_OPEN && auction.State != pb.AuctionState_PAUSED && auction.State != pb.AuctionState_CLOSING {
+		logger.IPrintf("Not repairing %s while it is %s\n", cmd.AuctionID, auction.State)
+		return &pb.RepairReply{
+			Repaired: false,
+		}
+	}
+
+	extended := auction.adoptEndTime(timeOrZero(cmd.EndTime))
+
+	// Repairs from old load balancers have no currency, the bid is in whatever the auction uses
+	if len(cmd.BidCurrency) > 0 && cmd.BidCurrency != auction.currency() {
+		logger.IPrintf("Not repairing %s with %s by %s, it is not in %s\n", cmd.AuctionID, money.Format(cmd.Bid, cmd.BidCurrency), cmd.Bidder, auction.currency())
+		return &pb.RepairReply{
+			Repaired: extended,
+		}
+	}
+
+	if auction.retracted(cmd.Bidder, cmd.Bid) {
+		logger.IPrintf("Not repairing %s with %d by %s, the bid was retracted\n", cmd.AuctionID, cmd.Bid, cmd.Bidder)
+		return &pb.RepairReply{
+			Repaired: extended,
+		}
+	}
+
+	bidTime := time.UnixMilli(cmd.BidTime)
+	if auction.Type == pb.AuctionType_VICKREY {
+		reply := auction.repairSealedBid(cmd.Bidder, cmd.Bid, bidTime)
+		if reply.Repaired {
+			auction.record(cmd.Bidder, cmd.Bid, bidTime, &pb.BidReply{Outcome: pb.BidReply_SUCCESS}, true)
+		}
+		reply.Repaired = reply.Repaired || extended
+		return reply
+	}
+
+	behind := auction.HighestBid < cmd.Bid ||
+		(auction.HighestBid == cmd.Bid && auction.HighestBidder != cmd.Bidder && bidTime.Before(auction.HighestBidTime))
+	if !behind {
+		return &pb.RepairReply{
+			Repaired: extended,
+		}
+	}
+
+	logger.IPrintf("Repairing highest value in %s. Old: %d (%s), new %d (%s)\n", cmd.AuctionID, auction.HighestBid, auction.HighestBidder, cmd.Bid, cmd.Bidder)
+	if auction.HighestBidder != cmd.Bidder {
+		// The maximum the bidder gave the other replica is not known here
+		auction.HighestMax = 0
+	}
+	auction.HighestBid = cmd.Bid
+	auction.HighestBidder = cmd.Bidder
+	auction.HighestBidTime = bidTime
+	auction.record(cmd.Bidder, cmd.Bid, bidTime, &pb.BidReply{Outcome: pb.BidReply_SUCCESS}, true)
+
+	// Someone accepted the asking price on another replica, so no one else can
+	if auction.Type == pb.AuctionType_DUTCH && auction.State == pb.AuctionState_OPEN {
+		auction.transition(pb.AuctionState_CLOSING, bidTime)
+	}
+
+	return &pb.RepairReply{
+		Repaired: true,
+	}
+}
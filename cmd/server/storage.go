@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/ap/DMP3/internal/wal"
+)
+
+/*
+Opens the bid log in the data directory and rebuilds the state from the
+latest snapshot and the commands logged after it
+*/
+func (n *Node) Recover(dataDir string) error {
+	log, err := wal.Open(dataDir)
+	if err != nil {
+		return err
+	}
+
+	snapshot, records, err := log.Replay()
+	if err != nil {
+		log.Close()
+		return err
+	}
+
+	state := NewState()
+	if snapshot != nil {
+		if err := json.Unmarshal(snapshot, state); err != nil {
+			log.Close()
+			return err
+		}
+	}
+
+	replayed := 0
+	for _, record := range records {
+		cmd := &Command{}
+		if err := json.Unmarshal(record, cmd); err != nil {
+			log.Close()
+			return err
+		}
+
+		// Left behind if we crashed between writing a snapshot and truncating the log
+		if cmd.Seq <= state.Seq {
+			continue
+		}
+
+		state.Apply(cmd)
+		replayed++
+	}
+	// Nobody is watching yet, anyone resuming from before the restart gets a snapshot
+	state.takeEvents()
+
+	logger.IPrintf("Recovered state at seq %d from %s, replayed %d commands\n", state.Seq, dataDir, replayed)
+	if err := state.verifyLedger(); err != nil {
+		logger.EPrintf("Recovered ledger does not verify: %v\n", err)
+	}
+
+	n.State = state
+	n.log = log
+	n.sinceSnapshot = replayed
+
+	return nil
+}
+
+/*
+Logs the command and applies it to the state. The caller must hold the write lock
+*/
+func (n *Node) execute(cmd *Command) (interface{}, error) {
+	cmd.Seq = n.State.Seq + 1
+
+	if n.log != nil {
+		record, err := json.Marshal(cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := n.log.Append(record); err != nil {
+			logger.EPrintf("Failed to append seq %d to the bid log: %v\n", cmd.Seq, err)
+			return nil, err
+		}
+	}
+
+	reply := n.State.Apply(cmd)
+	n.watchers.publish(n.State.takeEvents())
+	n.remember(cmd)
+
+	if n.log != nil {
+		n.sinceSnapshot++
+		if n.snapshotEvery > 0 && n.sinceSnapshot >= n.snapshotEvery {
+			if err := n.snapshot(); err != nil {
+				// The command is in the log, so it is safe to carry on and try again later
+				logger.EPrintf("Failed to write snapshot at seq %d: %v\n", n.State.Seq, err)
+			}
+		}
+	}
+
+	return reply, nil
+}
+
+func (n *Node) snapshot() error {
+	snapshot, err := json.Marshal(n.State)
+	if err != nil {
+		return err
+	}
+
+	if err := n.log.Snapshot(snapshot); err != nil {
+		return err
+	}
+
+	logger.IPrintf("Wrote snapshot at seq %d\n", n.State.Seq)
+	n.sinceSnapshot = 0
+
+	return nil
+}
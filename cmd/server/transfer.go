@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Number of executed commands kept in memory for replicas that fall behind
+	maxRecentCommands = 1024
+)
+
+var (
+	errNoHealthyPeer = errors.New("no healthy peer to fetch the state from")
+)
+
+/*
+Remembers an executed command so it can be sent to a replica catching up.
+The caller must hold the write lock
+*/
+func (n *Node) remember(cmd *Command) {
+	n.recent = append(n.recent, cmd)
+	if len(n.recent) > maxRecentCommands {
+		n.recent = n.recent[len(n.recent)-maxRecentCommands:]
+	}
+}
+
+/*
+Whether the given command is the one this node executed at its seq. A command
+too old to be kept cannot be compared and counts as another one. The caller
+must hold the lock
+*/
+func (n *Node) applied(cmd *Command) bool {
+	for _, executed := range n.recent {
+		if executed.Seq != cmd.Seq {
+			continue
+		}
+
+		a, errA := json.Marshal(executed)
+		b, errB := json.Marshal(cmd)
+		return errA == nil && errB == nil && bytes.Equal(a, b)
+	}
+
+	return false
+}
+
+/*
+Replaces the state with one fetched from a peer and writes it to a snapshot,
+as the commands that led to it are not in the bid log. The caller must hold
+the write lock
+*/
+func (n *Node) install(snapshot []byte) error {
+	state := &State{}
+	if err := json.Unmarshal(snapshot, state); err != nil {
+		return err
+	}
+
+	n.State = state
+	n.recent = nil
+	n.watchers.wakeAll()
+
+	if n.log != nil {
+		return n.snapshot()
+	}
+
+	return nil
+}
+
+/*
+Brings the node up to date with a healthy peer. With primary-backup the
+primary is asked for the commands this node missed, starting with the last
+one it executed, so a node that applied commands the primary never had
+fetches the whole state instead. Without replication every replica issues its
+own transitions, so neither their sequence numbers nor their commands line
+up. The whole state is fetched from the peer that applied the most writes,
+unless this node applied more than any of them
+*/
+func (n *Node) CatchUp(peers map[string]string, timeout time.Duration) error {
+	var source string
+	full := n.passive == nil
+
+	if n.passive != nil {
+		if n.passive.isPrimary() {
+			return nil
+		}
+
+		n.passive.lock.Lock()
+		source = n.passive.peers[n.passive.primaryID]
+		full = n.passive.diverged
+		n.passive.lock.Unlock()
+	} else {
+		var highest uint64
+		for id, addr := range peers {
+			if id == n.id {
+				continue
+			}
+
+			reply, err := fetch(addr, timeout, func(ctx context.Context, client pb.ReplicaClient) (interface{}, error) {
+				return client.Status(ctx, &pb.StatusRequest{})
+			})
+			if err != nil {
+				continue
+			}
+
+			if writes := reply.(*pb.StatusReply).Writes; len(source) == 0 || writes > highest {
+				source = addr
+				highest = writes
+			}
+		}
+
+		// Equal counts may still be different writes, so only a node ahead of every peer keeps its state
+		n.lock.RLock()
+		writes := n.State.Writes
+		n.lock.RUnlock()
+
+		if len(source) > 0 && highest < writes {
+			logger.IPrintf("Applied %d writes, more than any peer, keeping the local state\n", writes)
+			return nil
+		}
+	}
+
+	if len(source) == 0 {
+		return errNoHealthyPeer
+	}
+
+	n.lock.RLock()
+	fromSeq := n.State.Seq
+	// The last command executed here is fetched again to check the primary executed the same one
+	verify := !full && len(n.recent) > 0 && n.recent[len(n.recent)-1].Seq == fromSeq
+	n.lock.RUnlock()
+
+	request := &pb.StateRequest{FromSeq: fromSeq, Full: full}
+	if verify {
+		request.FromSeq--
+	}
+
+	// Fetched without holding the lock, a primary waiting on this node would otherwise wait on itself
+	reply, err := fetch(source, timeout, func(ctx context.Context, client pb.ReplicaClient) (interface{}, error) {
+		return client.FetchState(ctx, request)
+	})
+	if err != nil {
+		return err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.State.Seq != fromSeq {
+		return fmt.Errorf("moved from seq %d to %d while catching up", fromSeq, n.State.Seq)
+	}
+
+	state := reply.(*pb.StateReply)
+	if verify && state.Snapshot == nil && len(state.Commands) == 0 {
+		return n.diverge(fmt.Errorf("at seq %d, ahead of %s at seq %d", fromSeq, source, state.Seq))
+	}
+
+	if state.Snapshot != nil {
+		logger.IPrintf("Installing state at seq %d from %s, was at seq %d\n", state.Seq, source, fromSeq)
+		if err := n.install(state.Snapshot); err != nil {
+			return err
+		}
+
+		if n.passive != nil {
+			n.passive.lock.Lock()
+			n.passive.diverged = false
+			n.passive.lock.Unlock()
+		}
+
+		return nil
+	}
+
+	for i, command := range state.Commands {
+		cmd := &Command{}
+		if err := json.Unmarshal(command, cmd); err != nil {
+			return err
+		}
+
+		if verify && i == 0 {
+			if !n.applied(cmd) {
+				return n.diverge(fmt.Errorf("executed another command at seq %d than %s", cmd.Seq, source))
+			}
+			continue
+		}
+
+		if cmd.Seq != n.State.Seq+1 {
+			return fmt.Errorf("expected seq %d from %s, got %d", n.State.Seq+1, source, cmd.Seq)
+		}
+
+		if _, err := n.execute(cmd); err != nil {
+			return err
+		}
+	}
+
+	logger.IPrintf("Caught up from seq %d to %d with %s\n", fromSeq, n.State.Seq, source)
+	return nil
+}
+
+/*
+Marks the node as having applied commands the primary never had, so it
+fetches the whole state of the primary next time it catches up
+*/
+func (n *Node) diverge(err error) error {
+	n.passive.lock.Lock()
+	n.passive.diverged = true
+	n.passive.lock.Unlock()
+
+	return fmt.Errorf("%v, fetching the whole state next", err)
+}
+
+func fetch(addr string, timeout time.Duration, call func(context.Context, pb.ReplicaClient) (interface{}, error)) (interface{}, error) {
+	conn, err := grpc.Dial(addr, transport)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return call(ctx, pb.NewReplicaClient(conn))
+}
+
+func (n *Node) FetchState(_ context.Context, req *pb.StateRequest) (*pb.StateReply, error) {
+	if n.raft != nil {
+		return nil, status.Error(codes.FailedPrecondition, "raft replicas catch up through the raft log")
+	}
+
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	// The commands after from_seq are available if the oldest one kept is at most from_seq + 1
+	available := req.FromSeq == n.State.Seq ||
+		(len(n.recent) > 0 && req.FromSeq < n.State.Seq && n.recent[0].Seq <= req.FromSeq+1)
+
+	if req.Full || !available {
+		snapshot, err := json.Marshal(n.State)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.StateReply{
+			Seq:      n.State.Seq,
+			Snapshot: snapshot,
+		}, nil
+	}
+
+	commands := make([][]byte, 0, n.State.Seq-req.FromSeq)
+	for _, cmd := range n.recent {
+		if cmd.Seq <= req.FromSeq {
+			continue
+		}
+
+		command, err := json.Marshal(cmd)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+
+	return &pb.StateReply{
+		Seq:      n.State.Seq,
+		Commands: commands,
+	}, nil
+}
+
+func (n *Node) Status(_ context.Context, _ *pb.StatusRequest) (*pb.StatusReply, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return &pb.StatusReply{
+		Seq:    n.State.Seq,
+		Writes: n.State.Writes,
+	}, nil
+}
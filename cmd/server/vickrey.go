@@ -0,0 +1,130 @@
+package main
+
+import (
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+)
+
+// SealedBid is the best bid a single bidder placed in a sealed-bid auction
+type SealedBid struct {
+	Amount int64
+	Time   time.Time
+}
+
+// Whether the bids of a sealed-bid auction are still hidden, they are until it closes
+func (a *Auction) sealed() bool {
+	if a.Type != pb.AuctionType_VICKREY {
+		return false
+	}
+
+	return a.State == pb.AuctionState_SCHEDULED || a.State == pb.AuctionState_OPEN || a.State == pb.AuctionState_PAUSED
+}
+
+/*
+Records a sealed bid. A bidder may raise their own bid, but as the other bids
+are hidden a bid is only rejected if it is below the starting price or not
+above the bidder's previous one
+*/
+func (a *Auction) placeSealedBid(cmd *Command) *pb.BidReply {
+	if a.SealedBids == nil {
+		a.SealedBids = make(map[string]*SealedBid)
+	}
+
+	if cmd.Bid <= 0 || cmd.Bid < a.StartingPrice {
+		logger.IPrintf("Sealed bid %d by %s in %s is below the starting price, rejecting\n", cmd.Bid, cmd.Bidder, a.ID)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_BELOW_STARTING_PRICE)
+	}
+
+	if previous, ok := a.SealedBids[cmd.Bidder]; ok && cmd.Bid <= previous.Amount {
+		logger.IPrintf("Sealed bid %d by %s in %s is not above their previous bid, rejecting\n", cmd.Bid, cmd.Bidder, a.ID)
+		return rejectBid(pb.BidReply_FAIL, pb.BidReply_NOT_ABOVE_OWN_BID)
+	}
+
+	logger.IPrintf("Recording sealed bid %d by %s in %s\n", cmd.Bid, cmd.Bidder, a.ID)
+	a.SealedBids[cmd.Bidder] = &SealedBid{
+		Amount: cmd.Bid,
+		Time:   time.Unix(0, cmd.Time),
+	}
+
+	return &pb.BidReply{
+		Outcome: pb.BidReply_SUCCESS,
+	}
+}
+
+/*
+Opens the sealed bids. The highest bid wins, the earliest one if several are
+equal, and the winner pays the highest bid of any other bidder, but at least
+the reserve and starting price. Without another bidder or either price the
+winner pays their own bid
+*/
+func (a *Auction) resolveSealedBids() {
+	var winner string
+	var best *SealedBid
+	for bidder, bid := range a.SealedBids {
+		if best == nil || bid.Amount > best.Amount ||
+			(bid.Amount == best.Amount && (bid.Time.Before(best.Time) || (bid.Time.Equal(best.Time) && bidder < winner))) {
+			winner = bidder
+			best = bid
+		}
+	}
+
+	if best == nil {
+		return
+	}
+
+	price := best.Amount
+	second := false
+	for bidder, bid := range a.SealedBids {
+		if bidder != winner && (!second || bid.Amount > price) {
+			price = bid.Amount
+			second = true
+		}
+	}
+
+	floor := a.StartingPrice
+	if a.ReservePrice > floor {
+		floor = a.ReservePrice
+	}
+	if floor > 0 && (!second || price < floor) {
+		price = floor
+	}
+	if price > best.Amount {
+		// Below the reserve, the item is not sold anyway
+		price = best.Amount
+	}
+
+	logger.IPrintf("Opened %d sealed bids in %s, %s wins with %d and pays %d\n", len(a.SealedBids), a.ID, winner, best.Amount, price)
+	a.HighestBid = best.Amount
+	a.HighestBidder = winner
+	a.HighestBidTime = best.Time
+	a.Price = price
+}
+
+/*
+Adds a bid the load balancer saw on another replica to the sealed bids and
+opens them again, so the price accounts for it too. Bids are only revealed
+once the auction is closing, so that is the only time a repair can arrive
+*/
+func (a *Auction) repairSealedBid(bidder string, amount int64, bidTime time.Time) *pb.RepairReply {
+	if a.SealedBids == nil {
+		a.SealedBids = make(map[string]*SealedBid)
+	}
+
+	if previous, ok := a.SealedBids[bidder]; ok && previous.Amount >= amount {
+		return &pb.RepairReply{
+			Repaired: false,
+		}
+	}
+
+	logger.IPrintf("Repairing sealed bid of %s in %s to %d\n", bidder, a.ID, amount)
+	a.SealedBids[bidder] = &SealedBid{
+		Amount: amount,
+		Time:   bidTime,
+	}
+	a.resolveSealedBids()
+
+	return &pb.RepairReply{
+		Repaired: true,
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of recent events kept per auction for watchers resuming or falling behind
+const defaultWatchBuffer = 256
+
+// What a watcher gets told about, compared before and after every command
+type auctionView struct {
+	State         pb.AuctionState
+	HighestBid    int64
+	HighestBidder string
+	EndTime       time.Time
+}
+
+func (s *State) view(auctionID string) *auctionView {
+	auction, ok := s.Auctions[auctionID]
+	if !ok {
+		return nil
+	}
+
+	return &auctionView{
+		State:         auction.State,
+		HighestBid:    auction.HighestBid,
+		HighestBidder: auction.HighestBidder,
+		EndTime:       auction.EndTime,
+	}
+}
+
+/*
+Queues an event for every change the command made to the auction it touched.
+The sequence numbers are part of the auction, so replicas applying the same
+commands number the events the same way and a watcher can resume on any of
+them
+*/
+func (s *State) emitEvents(cmd *Command, before *auctionView) {
+	auction, ok := s.Auctions[cmd.AuctionID]
+	if !ok {
+		return
+	}
+
+	after := s.view(cmd.AuctionID)
+	if before == nil {
+		// Created by the command
+		before = &auctionView{State: -1, EndTime: after.EndTime}
+	}
+
+	kinds := make([]pb.AuctionEvent_Kind, 0, 3)
+	if after.State != before.State {
+		kinds = append(kinds, pb.AuctionEvent_STATE_CHANGED)
+	}
+	if after.HighestBid != before.HighestBid || after.HighestBidder != before.HighestBidder {
+		kinds = append(kinds, pb.AuctionEvent_NEW_HIGHEST_BID)
+	}
+	if after.EndTime.After(before.EndTime) {
+		kinds = append(kinds, pb.AuctionEvent_EXTENDED)
+	}
+
+	eventTime := time.Unix(0, cmd.Time)
+	for _, kind := range kinds {
+		auction.EventSeq++
+		s.events = append(s.events, &pb.AuctionEvent{
+			Seq:       auction.EventSeq,
+			Kind:      kind,
+			AuctionId: auction.ID,
+			Time:      eventTime.UnixMilli(),
+			Result:    auction.Result(eventTime),
+		})
+	}
+}
+
+// Returns the events queued since the last call
+func (s *State) takeEvents() []*pb.AuctionEvent {
+	events := s.events
+	s.events = nil
+
+	return events
+}
+
+// Whether the auction reached a state it never leaves, which ends every watch
+func finished(state pb.AuctionState) bool {
+	return state == pb.AuctionState_SETTLED || state == pb.AuctionState_CANCELLED
+}
+
+type watcher struct {
+	// Holds at most one wake up, so publishing never blocks on a slow watcher
+	notify chan struct{}
+}
+
+/*
+watchHub keeps the recent events of every auction and wakes up the watchers
+of an auction when there are new ones. Watchers read the events themselves,
+so one that cannot keep up only holds up its own stream
+*/
+type watchHub struct {
+	lock     sync.Mutex
+	size     int
+	events   map[string][]*pb.AuctionEvent
+	watchers map[string]map[*watcher]bool
+}
+
+func newWatchHub(size int) *watchHub {
+	if size <= 0 {
+		size = defaultWatchBuffer
+	}
+
+	return &watchHub{
+		size:     size,
+		events:   make(map[string][]*pb.AuctionEvent),
+		watchers: make(map[string]map[*watcher]bool),
+	}
+}
+
+func (h *watchHub) subscribe(auctionID string) *watcher {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	w := &watcher{notify: make(chan struct{}, 1)}
+	if h.watchers[auctionID] == nil {
+		h.watchers[auctionID] = make(map[*watcher]bool)
+	}
+	h.watchers[auctionID][w] = true
+
+	return w
+}
+
+func (h *watchHub) unsubscribe(auctionID string, w *watcher) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.watchers[auctionID], w)
+	if len(h.watchers[auctionID]) == 0 {
+		delete(h.watchers, auctionID)
+	}
+}
+
+/*
+Buffers the events and wakes up the watchers of their auctions. Called with
+the node write lock held, right after the commands that caused the events
+were applied
+*/
+func (h *watchHub) publish(events []*pb.AuctionEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, event := range events {
+		buffered := append(h.events[event.AuctionId], event)
+		if len(buffered) > h.size {
+			buffered = buffered[len(buffered)-h.size:]
+		}
+		h.events[event.AuctionId] = buffered
+
+		h.wake(event.AuctionId)
+	}
+}
+
+// Wakes up every watcher, as a state installed from a peer may change any auction
+func (h *watchHub) wakeAll() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for auctionID := range h.watchers {
+		h.wake(auctionID)
+	}
+}
+
+func (h *watchHub) wake(auctionID string) {
+	for w := range h.watchers[auctionID] {
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+/*
+Returns the buffered events of the auction after the sequence number, up to
+and including last. False if any of them are no longer buffered
+*/
+func (h *watchHub) since(auctionID string, after uint64, last uint64) ([]*pb.AuctionEvent, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	buffered := h.events[auctionID]
+	if len(buffered) == 0 || buffered[0].Seq > after+1 || buffered[len(buffered)-1].Seq < last {
+		return nil, false
+	}
+
+	result := make([]*pb.AuctionEvent, 0, last-after)
+	for _, event := range buffered {
+		if event.Seq > after && event.Seq <= last {
+			result = append(result, event)
+		}
+	}
+
+	return result, true
+}
+
+/*
+Returns what a watcher that saw every event up to the sequence number has not
+seen yet. If those events are no longer buffered, because the watcher fell
+too far behind or resumed on a replica that never had them, it gets a
+snapshot of the auction instead. A watcher ahead of this replica waits for it
+to catch up
+*/
+func (n *Node) eventsAfter(auctionID string, after uint64) ([]*pb.AuctionEvent, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	auction, ok := n.State.Auctions[auctionID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown auction %s", auctionID)
+	}
+
+	if after > auction.EventSeq || (after == auction.EventSeq && after > 0) {
+		return nil, nil
+	}
+
+	if after > 0 {
+		if events, ok := n.watchers.since(auctionID, after, auction.EventSeq); ok {
+			return events, nil
+		}
+		logger.IPrintf("Events of %s after %d are no longer buffered, sending a snapshot\n", auctionID, after)
+	}
+
+	now := time.Now()
+	return []*pb.AuctionEvent{{
+		Seq:       auction.EventSeq,
+		Kind:      pb.AuctionEvent_SNAPSHOT,
+		AuctionId: auction.ID,
+		Time:      now.UnixMilli(),
+		Result:    auction.Result(now),
+	}}, nil
+}
+
+/*
+Streams the events of an auction until it is settled or cancelled. Any
+replica can serve a watcher, followers and backups apply the same commands as
+the leader or primary, just a little later
+*/
+func (n *Node) WatchAuction(req *pb.WatchRequest, stream pb.Auction_WatchAuctionServer) error {
+	auctionID := auctionIDOrDefault(req.GetAuctionId())
+
+	// Subscribe before reading, so no event published in between is missed
+	w := n.watchers.subscribe(auctionID)
+	defer n.watchers.unsubscribe(auctionID, w)
+
+	logger.IPrintf("Watching %s from seq %d\n", auctionID, req.GetAfterSeq())
+
+	last := req.GetAfterSeq()
+	for {
+		events, err := n.eventsAfter(auctionID, last)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+
+			last = event.Seq
+			if finished(event.GetResult().GetAuctionState()) {
+				return nil
+			}
+		}
+
+		select {
+		case <-w.notify:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata key the bearer token is sent in
+const authorizationKey = "authorization"
+
+// Header of every token, they are all signed with HMAC-SHA256
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+var (
+	errMalformed = errors.New("malformed token")
+	errSignature = errors.New("token signature does not match")
+	errExpired   = errors.New("token expired")
+)
+
+// Claims of a bidder token, in the JWT registered claim names
+type Claims struct {
+	// Bidder the token was issued to
+	Subject string `json:"sub"`
+	// Unix time in seconds
+	IssuedAt int64 `json:"iat"`
+	// Unix time in seconds the token expires, never if 0
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+func sign(secret []byte, signed string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+/*
+Issues a token for the bidder, a JWT signed with the secret. It expires
+after ttl, or never if ttl is 0
+*/
+func Issue(secret []byte, bidder string, ttl time.Duration, now time.Time) (string, error) {
+	claims := Claims{
+		Subject:  bidder,
+		IssuedAt: now.Unix(),
+	}
+	if ttl > 0 {
+		claims.ExpiresAt = now.Add(ttl).Unix()
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + sign(secret, signed), nil
+}
+
+// Checks the token was signed with the secret and has not expired, and returns its claims
+func Verify(secret []byte, token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return nil, errMalformed
+	}
+
+	if !hmac.Equal([]byte(sign(secret, parts[0]+"."+parts[1])), []byte(parts[2])) {
+		return nil, errSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errMalformed
+	}
+
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil || len(claims.Subject) == 0 {
+		return nil, errMalformed
+	}
+
+	if claims.ExpiresAt > 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, errExpired
+	}
+
+	return claims, nil
+}
+
+// Sends the token as a bearer token with every call made with the context
+func WithToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+}
+
+type bidderKey struct{}
+
+// Bidder the caller authenticated as, if the server authenticates callers at all
+func Bidder(ctx context.Context) (string, bool) {
+	bidder, ok := ctx.Value(bidderKey{}).(string)
+	return bidder, ok
+}
+
+/*
+Checks the caller may act as the bidder. Callers can act as anyone when the
+server does not authenticate them
+*/
+func Authorize(ctx context.Context, bidder string) error {
+	caller, ok := Bidder(ctx)
+	if !ok || caller == bidder {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "authenticated as %s, cannot act as %q", caller, bidder)
+}
+
+// Verifies the bearer token in the metadata of the call and remembers who sent it
+func authenticate(ctx context.Context, secret []byte) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationKey) {
+		token := strings.TrimPrefix(value, "Bearer ")
+		if token == value {
+			continue
+		}
+
+		claims, err := Verify(secret, token, time.Now())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid bearer token: %v", err)
+		}
+
+		return context.WithValue(ctx, bidderKey{}, claims.Subject), nil
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+}
+
+// Whether the method belongs to the service, which has no package
+func inService(method string, service string) bool {
+	return strings.HasPrefix(method, "/"+service+"/")
+}
+
+// Authenticates every unary call to the service, calls to other services pass untouched
+func UnaryInterceptor(secret []byte, service string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !inService(info.FullMethod, service) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// A server stream with the context of the authenticated caller
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// Authenticates every stream of the service when it is opened
+func StreamInterceptor(secret []byte, service string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !inService(info.FullMethod, service) {
+			return handler(srv, stream)
+		}
+
+		ctx, err := authenticate(stream.Context(), secret)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
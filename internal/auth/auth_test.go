@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	secret = []byte("secret")
+	issued = time.Unix(1700000000, 0)
+)
+
+// Signs a token with the header and claims as given, to forge ones Issue never makes
+func forge(header string, claims string) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return signed + "." + sign(secret, signed)
+}
+
+// Token issued to bob that expires after a minute
+func token(t *testing.T) string {
+	token, err := Issue(secret, "bob", time.Minute, issued)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	return token
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+		now   time.Time
+		err   error
+	}{
+		{name: "valid", token: token, now: issued},
+		{name: "just before expiry", token: token, now: issued.Add(time.Minute - time.Second)},
+		{name: "expired", token: token, now: issued.Add(time.Minute), err: errExpired},
+		{
+			name: "never expires",
+			token: func(t *testing.T) string {
+				token, err := Issue(secret, "bob", 0, issued)
+				if err != nil {
+					t.Fatalf("Issue() error = %v", err)
+				}
+				return token
+			},
+			now: issued.Add(24 * 365 * time.Hour),
+		},
+		{
+			name: "other secret",
+			token: func(t *testing.T) string {
+				token, err := Issue([]byte("other"), "bob", time.Minute, issued)
+				if err != nil {
+					t.Fatalf("Issue() error = %v", err)
+				}
+				return token
+			},
+			now: issued,
+			err: errSignature,
+		},
+		{
+			name: "changed claims",
+			token: func(t *testing.T) string {
+				parts := strings.Split(token(t), ".")
+				parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"mallory","iat":1700000000}`))
+				return strings.Join(parts, ".")
+			},
+			now: issued,
+			err: errSignature,
+		},
+		{
+			name: "bad signature encoding",
+			token: func(t *testing.T) string {
+				return token(t) + "!"
+			},
+			now: issued,
+			err: errSignature,
+		},
+		{
+			name: "alg none",
+			token: func(t *testing.T) string {
+				parts := strings.Split(forge(`{"alg":"none","typ":"JWT"}`, `{"sub":"bob","iat":1700000000}`), ".")
+				return parts[0] + "." + parts[1] + "."
+			},
+			now: issued,
+			err: errMalformed,
+		},
+		{
+			name: "other alg",
+			token: func(t *testing.T) string {
+				return forge(`{"alg":"HS512","typ":"JWT"}`, `{"sub":"bob","iat":1700000000}`)
+			},
+			now: issued,
+			err: errMalformed,
+		},
+		{
+			name: "no subject",
+			token: func(t *testing.T) string {
+				return forge(`{"alg":"HS256","typ":"JWT"}`, `{"iat":1700000000}`)
+			},
+			now: issued,
+			err: errMalformed,
+		},
+		{
+			name: "claims not json",
+			token: func(t *testing.T) string {
+				return forge(`{"alg":"HS256","typ":"JWT"}`, `bob`)
+			},
+			now: issued,
+			err: errMalformed,
+		},
+		{
+			name: "two parts",
+			token: func(t *testing.T) string {
+				return strings.Join(strings.Split(token(t), ".")[:2], ".")
+			},
+			now: issued,
+			err: errMalformed,
+		},
+		{name: "empty", token: func(*testing.T) string { return "" }, now: issued, err: errMalformed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims, err := Verify(secret, test.token(t), test.now)
+			if err != test.err {
+				t.Fatalf("Verify() error = %v, want %v", err, test.err)
+			}
+			if err == nil && claims.Subject != "bob" {
+				t.Errorf("Verify() subject = %s, want bob", claims.Subject)
+			}
+		})
+	}
+}
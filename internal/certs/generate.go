@@ -0,0 +1,199 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Roles a certificate is issued for, kept in its organizational unit
+const (
+	RoleLB      = "lb"
+	RoleReplica = "replica"
+	RoleClient  = "client"
+)
+
+// Organization of every certificate generated
+const organization = "DMP3 dev cluster"
+
+/*
+Generates certificates for a dev cluster in the directory: a CA, unless the
+directory already has one, and a certificate and key for each node signed by
+it. The load balancer certificate is named lb and valid for lbHosts, each
+replica gets a certificate of its own named replica-<host> and valid for just
+its host, so a replica cannot pose as another. Hosts may be names or IP
+addresses, and these certificates can be used to both serve and dial. The
+client certificate is named client and can only dial
+*/
+func Generate(dir string, lbHosts []string, replicaHosts []string, validity time.Duration) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	ca, caKey, err := loadCA(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		ca, caKey, err = newCA(dir, validity)
+	}
+	if err != nil {
+		return err
+	}
+
+	serve := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}
+	if err := newCertificate(dir, RoleLB, RoleLB, lbHosts, serve, ca, caKey, validity); err != nil {
+		return err
+	}
+	for _, host := range replicaHosts {
+		if err := newCertificate(dir, ReplicaName(host), RoleReplica, []string{host}, serve, ca, caKey, validity); err != nil {
+			return err
+		}
+	}
+
+	return newCertificate(dir, RoleClient, RoleClient, nil, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, ca, caKey, validity)
+}
+
+// Name of the certificate Generate writes for the replica on the host
+func ReplicaName(host string) string {
+	return RoleReplica + "-" + host
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+}
+
+// Writes the certificate and its key next to each other as name.pem and name-key.pem
+func write(dir string, name string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := ioutil.WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0644); err != nil {
+		return err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return ioutil.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600)
+}
+
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	certPEM, err := ioutil.ReadFile(filepath.Join(dir, "ca.pem"))
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(filepath.Join(dir, "ca-key.pem"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("malformed CA in %s", dir)
+	}
+
+	ca, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.IPrintf("Using the CA in %s\n", dir)
+	return ca, key, nil
+}
+
+func newCA(dir string, validity time.Duration) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{organization}, CommonName: "DMP3 dev CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := write(dir, "ca", der, key); err != nil {
+		return nil, nil, err
+	}
+
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.IPrintf("Generated a CA in %s\n", dir)
+	return ca, key, nil
+}
+
+func newCertificate(dir string, name string, role string, hosts []string, usage []x509.ExtKeyUsage, ca *x509.Certificate, caKey crypto.Signer, validity time.Duration) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{organization},
+			OrganizationalUnit: []string{role},
+			CommonName:         name,
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: usage,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	if err := write(dir, name, der, key); err != nil {
+		return err
+	}
+
+	logger.IPrintf("Generated the %s certificate in %s\n", name, dir)
+	return nil
+}
@@ -0,0 +1,49 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Each replica certificate is valid for its own host and no other replica's
+func TestGenerateReplicas(t *testing.T) {
+	dir := t.TempDir()
+	hosts := []string{"server-1", "server-2", "172.16.238.5"}
+	if err := Generate(dir, []string{"loadbalancer"}, hosts, time.Hour); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, host := range hosts {
+		t.Run(host, func(t *testing.T) {
+			certPEM, err := ioutil.ReadFile(filepath.Join(dir, ReplicaName(host)+".pem"))
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			block, _ := pem.Decode(certPEM)
+			if block == nil {
+				t.Fatalf("no PEM block in the certificate of %s", host)
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatalf("ParseCertificate() error = %v", err)
+			}
+
+			if !hasRole(cert, []string{RoleReplica}) {
+				t.Errorf("certificate of %s has roles %v, want %s", host, cert.Subject.OrganizationalUnit, RoleReplica)
+			}
+			for _, other := range append(hosts, "loadbalancer") {
+				err := cert.VerifyHostname(other)
+				if other == host && err != nil {
+					t.Errorf("VerifyHostname(%s) error = %v", other, err)
+				}
+				if other != host && err == nil {
+					t.Errorf("VerifyHostname(%s) of the certificate of %s error = nil, want an error", other, host)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ap/DMP3/internal/logging"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// How often the files are checked for changes, at most once per handshake
+const reloadInterval = time.Second
+
+var (
+	logger = logging.New()
+
+	errNoCertificate = errors.New("no certificate configured")
+	errNoCA          = errors.New("no CA configured")
+	errRole          = errors.New("peer certificate has no accepted role")
+)
+
+/*
+Reloader holds a certificate, its key and the CA that signs the peers. The
+files are checked for changes on handshakes, so a renewed certificate or CA
+is picked up without a restart. New connections use it, open ones keep the
+certificate they were made with
+*/
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	lock     sync.Mutex
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+	// Latest modification time of the files when they were loaded
+	modified time.Time
+	checked  time.Time
+}
+
+/*
+Loads the certificate and key, if given, and the CA, if given. A reloader
+without a certificate can only verify servers, one without a CA can only
+present its certificate
+*/
+func NewReloader(certFile string, keyFile string, caFile string) (*Reloader, error) {
+	if (len(certFile) == 0) != (len(keyFile) == 0) {
+		return nil, errors.New("a certificate needs its key and a key its certificate")
+	}
+
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Reloader) files() []string {
+	var files []string
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if len(file) > 0 {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// Latest modification time of the files
+func (r *Reloader) latest() (time.Time, error) {
+	var latest time.Time
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// Loads the files, keeping what was loaded before if any of them is broken
+func (r *Reloader) load() error {
+	modified, err := r.latest()
+	if err != nil {
+		return err
+	}
+
+	var cert *tls.Certificate
+	if len(r.certFile) > 0 {
+		pair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return err
+		}
+		cert = &pair
+	}
+
+	var pool *x509.CertPool
+	if len(r.caFile) > 0 {
+		pem, err := ioutil.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in %s", r.caFile)
+		}
+	}
+
+	r.cert = cert
+	r.pool = pool
+	r.modified = modified
+	r.checked = time.Now()
+
+	return nil
+}
+
+// Returns the current certificate and CA, reloading them first if the files changed
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if time.Since(r.checked) < reloadInterval {
+		return r.cert, r.pool
+	}
+	r.checked = time.Now()
+
+	if modified, err := r.latest(); err != nil {
+		logger.EPrintf("Failed to check %v for changes: %v\n", r.files(), err)
+	} else if modified.After(r.modified) {
+		if err := r.load(); err != nil {
+			logger.EPrintf("Failed to reload %v, keeping the previous certificates: %v\n", r.files(), err)
+		} else {
+			logger.IPrintf("Reloaded %v\n", r.files())
+		}
+	}
+
+	return r.cert, r.pool
+}
+
+/*
+Credentials for serving TLS. With roles, clients must present a certificate
+signed by the CA for one of them, without any only the server is
+authenticated
+*/
+func (r *Reloader) ServerCredentials(roles ...string) credentials.TransportCredentials {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// gRPC only adds h2 to this config, not to the ones returned for each client
+		NextProtos: []string{"h2"},
+	}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cert, pool := r.current()
+		if cert == nil {
+			return nil, errNoCertificate
+		}
+
+		config := &tls.Config{
+			MinVersion:   base.MinVersion,
+			NextProtos:   base.NextProtos,
+			Certificates: []tls.Certificate{*cert},
+		}
+		if len(roles) > 0 {
+			if pool == nil {
+				return nil, errNoCA
+			}
+
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+			config.ClientCAs = pool
+			config.VerifyPeerCertificate = requireRole(roles)
+		}
+
+		return config, nil
+	}
+
+	return credentials.NewTLS(base)
+}
+
+/*
+Credentials for dialing TLS. The server must present a certificate signed by
+the CA for the host dialed, and the certificate is presented to servers that
+ask for one. The server is verified against the current CA rather than the
+one loaded when the credentials were made, so the CA can be reloaded too
+*/
+func (r *Reloader) ClientCredentials() credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Verified by VerifyConnection instead
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			_, pool := r.current()
+			if pool == nil {
+				return errNoCA
+			}
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range state.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				DNSName:       state.ServerName,
+				Roots:         pool,
+				Intermediates: intermediates,
+			})
+			return err
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			if cert == nil {
+				// Sending none lets the server tell the client it needs one
+				return &tls.Certificate{}, nil
+			}
+
+			return cert, nil
+		},
+	})
+}
+
+// Whether the certificate was issued for one of the roles
+func hasRole(cert *x509.Certificate, roles []string) bool {
+	for _, role := range cert.Subject.OrganizationalUnit {
+		for _, accepted := range roles {
+			if role == accepted {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Accepts a verified peer certificate only if it was issued for one of the roles
+func requireRole(roles []string) func([][]byte, [][]*x509.Certificate) error {
+	return func(_ [][]byte, chains [][]*x509.Certificate) error {
+		for _, chain := range chains {
+			if hasRole(chain[0], roles) {
+				return nil
+			}
+		}
+
+		return errRole
+	}
+}
+
+/*
+Whether the caller presented a verified certificate issued for one of the
+roles. Callers over plaintext have no role
+*/
+func PeerHasRole(ctx context.Context, roles ...string) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return false
+	}
+
+	for _, chain := range info.State.VerifiedChains {
+		if hasRole(chain[0], roles) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,218 @@
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Generates a dev cluster's certificates in a directory of its own, with a replica on localhost
+func generate(t *testing.T) string {
+	dir := t.TempDir()
+	if err := Generate(dir, []string{"localhost", "127.0.0.1"}, []string{"localhost"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	return dir
+}
+
+// Loads the certificate and key generated for the role, for replicas the one on localhost
+func reloader(t *testing.T, dir string, role string) *Reloader {
+	name := role
+	if role == RoleReplica {
+		name = ReplicaName("localhost")
+	}
+
+	r, err := NewReloader(filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem"), filepath.Join(dir, "ca.pem"))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	return r
+}
+
+// Shakes hands over loopback, returning the protocol the server negotiated or the error of either side
+func handshake(t *testing.T, server credentials.TransportCredentials, client credentials.TransportCredentials) (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer lis.Close()
+
+	clientErr := make(chan error, 1)
+	go func() {
+		conn, err := net.Dial("tcp", lis.Addr().String())
+		if err == nil {
+			defer conn.Close()
+			_, _, err = client.ClientHandshake(context.Background(), "localhost", conn)
+		}
+		clientErr <- err
+	}()
+
+	conn, err := lis.Accept()
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	defer conn.Close()
+
+	// With TLS 1.3 the client is done before the server has checked its certificate
+	_, info, err := server.ServerHandshake(conn)
+	if err := <-clientErr; err != nil {
+		return "", err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return info.(credentials.TLSInfo).State.NegotiatedProtocol, nil
+}
+
+func TestRequireRole(t *testing.T) {
+	dir := generate(t)
+
+	tests := []struct {
+		name  string
+		role  string
+		roles []string
+		err   error
+	}{
+		{name: "load balancer", role: RoleLB, roles: []string{RoleLB, RoleReplica}},
+		{name: "replica", role: RoleReplica, roles: []string{RoleLB, RoleReplica}},
+		{name: "client", role: RoleClient, roles: []string{RoleLB, RoleReplica}, err: errRole},
+		{name: "replica where only load balancers are accepted", role: RoleReplica, roles: []string{RoleLB}, err: errRole},
+		{name: "no role accepted", role: RoleLB, err: errRole},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cert, _ := reloader(t, dir, test.role).current()
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				t.Fatalf("ParseCertificate() error = %v", err)
+			}
+
+			if err := requireRole(test.roles)(nil, [][]*x509.Certificate{{leaf}}); err != test.err {
+				t.Errorf("requireRole() error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestServerCredentials(t *testing.T) {
+	dir := generate(t)
+	server := reloader(t, dir, RoleReplica).ServerCredentials(RoleLB, RoleReplica)
+
+	tests := []struct {
+		name    string
+		role    string
+		wantErr bool
+	}{
+		{name: "load balancer", role: RoleLB},
+		{name: "replica", role: RoleReplica},
+		{name: "client", role: RoleClient, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protocol, err := handshake(t, server, reloader(t, dir, test.role).ClientCredentials())
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("handshake() with a %s certificate error = nil, want an error", test.role)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("handshake() error = %v", err)
+			}
+			// gRPC clients insist on h2, which the config for each client must offer too
+			if protocol != "h2" {
+				t.Errorf("handshake() negotiated %q, want h2", protocol)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := generate(t)
+	r := reloader(t, dir, RoleLB)
+	before, _ := r.current()
+
+	// Renews every certificate with the same CA, which the files may not look newer than yet
+	if err := Generate(dir, []string{"localhost"}, []string{"localhost"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	for _, file := range r.files() {
+		if err := os.Chtimes(file, later, later); err != nil {
+			t.Fatalf("Chtimes() error = %v", err)
+		}
+	}
+
+	// Not checked again until the interval has passed
+	if cert, _ := r.current(); cert != before {
+		t.Fatalf("current() reloaded within %v", reloadInterval)
+	}
+
+	r.checked = time.Time{}
+	renewed, _ := r.current()
+	if renewed == before || string(renewed.Certificate[0]) == string(before.Certificate[0]) {
+		t.Fatalf("current() kept the certificate after the files changed")
+	}
+
+	// A broken certificate is not loaded, the renewed one is kept
+	if err := ioutil.WriteFile(r.certFile, []byte("broken"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	later = later.Add(time.Minute)
+	if err := os.Chtimes(r.certFile, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	r.checked = time.Time{}
+	if cert, _ := r.current(); cert != renewed {
+		t.Errorf("current() dropped the certificate for a broken one")
+	}
+}
+
+// The renewed certificate is what new handshakes present
+func TestReloadHandshake(t *testing.T) {
+	dir := generate(t)
+	r := reloader(t, dir, RoleReplica)
+	server := r.ServerCredentials(RoleLB)
+	client := reloader(t, dir, RoleLB).ClientCredentials()
+
+	if _, err := handshake(t, server, client); err != nil {
+		t.Fatalf("handshake() error = %v", err)
+	}
+
+	// A certificate for another host no longer verifies for localhost
+	if err := Generate(dir, []string{"localhost"}, []string{"example.com"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	other := filepath.Join(dir, ReplicaName("example.com"))
+	if err := os.Rename(other+".pem", r.certFile); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if err := os.Rename(other+"-key.pem", r.keyFile); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	for _, file := range r.files() {
+		if err := os.Chtimes(file, later, later); err != nil {
+			t.Fatalf("Chtimes() error = %v", err)
+		}
+	}
+	r.checked = time.Time{}
+
+	if _, err := handshake(t, server, client); err == nil {
+		t.Errorf("handshake() with the reloaded certificate for example.com error = nil, want an error")
+	}
+}
@@ -0,0 +1,109 @@
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/ap/DMP3/api"
+)
+
+// Hashes every field of the entry but the hash itself, chaining it to the previous entry
+func Hash(entry *api.LedgerEntry) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%s|%s|%d|%s|%d|%s|%s",
+		entry.GetSeq(), entry.GetInvoiceId(), entry.GetAuctionId(), entry.GetAccount(), entry.GetSide(),
+		entry.GetAmount().GetMinorUnits(), entry.GetAmount().GetCurrency(), entry.GetTime(), entry.GetMemo(), entry.GetPrevHash())))
+
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+Checks a whole ledger from its first entry: every entry must follow the one
+before it and still have the hash it was booked with, and the debits of every
+invoice must match its credits
+*/
+func Verify(entries []*api.LedgerEntry) error {
+	v := &Verifier{}
+	if err := v.Add(entries...); err != nil {
+		return err
+	}
+
+	return v.Balanced()
+}
+
+/*
+Verifier verifies a ledger that only ever grows. It remembers how far the
+ledger was verified, so the entries booked since are all that is left to
+verify
+*/
+type Verifier struct {
+	count int
+	head  string
+	// What each invoice that does not balance is off by
+	unbalanced map[string]int64
+}
+
+// Number of entries verified so far
+func (v *Verifier) Count() int {
+	return v.count
+}
+
+// Hash of the last entry verified, empty if none was
+func (v *Verifier) Head() string {
+	return v.head
+}
+
+/*
+Verifies entries booked after the ones verified so far, in order. It stops
+at the first entry that does not verify, the ones before it stay verified
+*/
+func (v *Verifier) Add(entries ...*api.LedgerEntry) error {
+	if v.unbalanced == nil {
+		v.unbalanced = make(map[string]int64)
+	}
+
+	for _, entry := range entries {
+		if entry.GetSeq() != uint64(v.count)+1 {
+			return fmt.Errorf("entry %d has seq %d", v.count+1, entry.GetSeq())
+		}
+		if entry.GetPrevHash() != v.head {
+			return fmt.Errorf("entry %d does not follow entry %d", entry.GetSeq(), v.count)
+		}
+		if Hash(entry) != entry.GetHash() {
+			return fmt.Errorf("entry %d was changed after it was booked", entry.GetSeq())
+		}
+
+		difference := v.unbalanced[entry.GetInvoiceId()]
+		if entry.GetSide() == api.LedgerEntry_DEBIT {
+			difference += entry.GetAmount().GetMinorUnits()
+		} else {
+			difference -= entry.GetAmount().GetMinorUnits()
+		}
+		if difference == 0 {
+			delete(v.unbalanced, entry.GetInvoiceId())
+		} else {
+			v.unbalanced[entry.GetInvoiceId()] = difference
+		}
+
+		v.count++
+		v.head = entry.GetHash()
+	}
+
+	return nil
+}
+
+// Checks the debits of every invoice verified so far match its credits
+func (v *Verifier) Balanced() error {
+	invoiceIDs := make([]string, 0, len(v.unbalanced))
+	for invoiceID := range v.unbalanced {
+		invoiceIDs = append(invoiceIDs, invoiceID)
+	}
+	sort.Strings(invoiceIDs)
+
+	if len(invoiceIDs) > 0 {
+		return fmt.Errorf("invoice %s is off by %d", invoiceIDs[0], v.unbalanced[invoiceIDs[0]])
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ap/DMP3/api"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+A chained ledger of two sold invoices, each a debit of the buyer against a
+credit of the seller and one of the platform fee
+*/
+func chain() []*api.LedgerEntry {
+	lines := []struct {
+		invoiceID string
+		account   string
+		side      api.LedgerEntry_Side
+		amount    int64
+	}{
+		{"INV-a1", "buyer:bob", api.LedgerEntry_DEBIT, 1000},
+		{"INV-a1", "seller:house", api.LedgerEntry_CREDIT, 950},
+		{"INV-a1", "platform:fees", api.LedgerEntry_CREDIT, 50},
+		{"INV-a2", "buyer:ann", api.LedgerEntry_DEBIT, 200},
+		{"INV-a2", "seller:house", api.LedgerEntry_CREDIT, 200},
+	}
+
+	var entries []*api.LedgerEntry
+	prevHash := ""
+	for i, line := range lines {
+		entry := &api.LedgerEntry{
+			Seq:       uint64(i) + 1,
+			InvoiceId: line.invoiceID,
+			AuctionId: strings.TrimPrefix(line.invoiceID, "INV-"),
+			Account:   line.account,
+			Side:      line.side,
+			Amount:    &api.Money{MinorUnits: line.amount, Currency: "DKK"},
+			Time:      1700000000000,
+			PrevHash:  prevHash,
+		}
+		entry.Hash = Hash(entry)
+		prevHash = entry.Hash
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// Hashes the entry again, as someone covering up a change would
+func rehash(entry *api.LedgerEntry) {
+	entry.Hash = Hash(entry)
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func([]*api.LedgerEntry) []*api.LedgerEntry
+		// Part of the error, the ledger verifies if empty
+		err string
+	}{
+		{
+			name:   "intact",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry { return entries },
+		},
+		{
+			name:   "empty",
+			tamper: func([]*api.LedgerEntry) []*api.LedgerEntry { return nil },
+		},
+		{
+			name: "amount changed",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[1].Amount.MinorUnits = 900
+				return entries
+			},
+			err: "entry 2 was changed after it was booked",
+		},
+		{
+			name: "account changed",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[0].Account = "buyer:mallory"
+				return entries
+			},
+			err: "entry 1 was changed after it was booked",
+		},
+		{
+			name: "changed and hashed again",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[1].Memo = "covered up"
+				rehash(entries[1])
+				return entries
+			},
+			err: "entry 3 does not follow entry 2",
+		},
+		{
+			name: "whole tail hashed again",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[3].Amount.MinorUnits = 100
+				rehash(entries[3])
+				entries[4].PrevHash = entries[3].Hash
+				rehash(entries[4])
+				return entries
+			},
+			err: "invoice INV-a2 is off by -100",
+		},
+		{
+			name: "entry removed",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				return append(entries[:2], entries[3:]...)
+			},
+			err: "entry 3 has seq 4",
+		},
+		{
+			name: "entries swapped",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[3], entries[4] = entries[4], entries[3]
+				return entries
+			},
+			err: "entry 4 has seq 5",
+		},
+		{
+			name: "forged hash",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[4].Hash = strings.Repeat("0", 64)
+				return entries
+			},
+			err: "entry 5 was changed after it was booked",
+		},
+		{
+			name: "first entry chained to nothing",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				entries[0].PrevHash = strings.Repeat("0", 64)
+				rehash(entries[0])
+				return entries[:1]
+			},
+			err: "entry 1 does not follow entry 0",
+		},
+		{
+			name: "invoice cut short",
+			tamper: func(entries []*api.LedgerEntry) []*api.LedgerEntry {
+				return entries[:4]
+			},
+			err: "invoice INV-a2 is off by 200",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Verify(test.tamper(chain()))
+			if len(test.err) == 0 && err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			} else if len(test.err) > 0 && (err == nil || err.Error() != test.err) {
+				t.Fatalf("Verify() error = %v, want %s", err, test.err)
+			}
+		})
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	entries := chain()
+	v := &Verifier{}
+
+	if err := v.Add(entries[:2]...); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := v.Balanced(); err == nil {
+		t.Errorf("Balanced() with an invoice half booked = nil, want an error")
+	}
+
+	if err := v.Add(entries[2:]...); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := v.Balanced(); err != nil {
+		t.Errorf("Balanced() error = %v", err)
+	}
+	if v.Count() != len(entries) || v.Head() != entries[len(entries)-1].Hash {
+		t.Errorf("Count(), Head() = %d, %s, want %d, %s", v.Count(), v.Head(), len(entries), entries[len(entries)-1].Hash)
+	}
+
+	// An entry that does not follow the head is not verified, and the ones before it stay verified
+	forged := proto.Clone(entries[0]).(*api.LedgerEntry)
+	forged.Seq = uint64(len(entries)) + 1
+	rehash(forged)
+	if err := v.Add(forged); err == nil {
+		t.Errorf("Add() of an entry not chained to the head = nil, want an error")
+	}
+	if v.Count() != len(entries) {
+		t.Errorf("Count() = %d, want %d", v.Count(), len(entries))
+	}
+}
@@ -0,0 +1,173 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ap/DMP3/api"
+)
+
+// Currency of auctions created without one
+const DefaultCurrency = "DKK"
+
+// Number of decimals of the currencies that do not have two
+var exponents = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+var errMalformed = errors.New("malformed amount")
+
+// Whether the code looks like an ISO 4217 code, three upper case letters
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func New(minorUnits int64, currency string) *api.Money {
+	return &api.Money{
+		MinorUnits: minorUnits,
+		Currency:   currency,
+	}
+}
+
+/*
+Returns the amount of a money field, falling back to the deprecated int32
+field an old client sent instead. The currency is empty for those, it is
+whatever the auction uses
+*/
+func OrLegacy(m *api.Money, legacy int32) (int64, string) {
+	if m != nil {
+		return m.MinorUnits, m.Currency
+	}
+
+	return int64(legacy), ""
+}
+
+// Amount of a money field, or of the deprecated int32 field an old client sent instead
+func Amount(m *api.Money, legacy int32) int64 {
+	amount, _ := OrLegacy(m, legacy)
+	return amount
+}
+
+/*
+Whether the money field is in the currency or does not name one. The
+deprecated int32 fields are always in the currency of the auction
+*/
+func InCurrency(m *api.Money, currency string) bool {
+	return m == nil || len(m.Currency) == 0 || m.Currency == currency
+}
+
+// Whether every price of the auction to create is in its currency
+func PricesInCurrency(req *api.CreateAuctionRequest, currency string) bool {
+	prices := []*api.Money{
+		req.GetMinIncrementAmount(),
+		req.GetReservePriceAmount(),
+		req.GetStartingPriceAmount(),
+		req.GetDutch().GetStartPriceAmount(),
+		req.GetDutch().GetPriceStepAmount(),
+		req.GetDutch().GetFloorPriceAmount(),
+	}
+	for _, tier := range req.GetIncrements() {
+		prices = append(prices, tier.GetBelowAmount(), tier.GetIncrementAmount())
+	}
+
+	for _, price := range prices {
+		if !InCurrency(price, currency) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Caps an amount to the range of the deprecated int32 fields
+func Legacy(minorUnits int64) int32 {
+	if minorUnits > math.MaxInt32 {
+		return math.MaxInt32
+	} else if minorUnits < math.MinInt32 {
+		return math.MinInt32
+	}
+
+	return int32(minorUnits)
+}
+
+// Amount of a result, from old replicas that only sent the int32 result too
+func Result(reply *api.ResultReply) int64 {
+	amount, _ := OrLegacy(reply.GetResultAmount(), reply.GetResult())
+	return amount
+}
+
+// Number of decimals in the currency
+func exponent(currency string) int {
+	if exponent, ok := exponents[currency]; ok {
+		return exponent
+	}
+
+	return 2
+}
+
+// Formats an amount in minor units with the decimals of its currency, like 12.50 DKK
+func Format(minorUnits int64, currency string) string {
+	exponent := exponent(currency)
+	if exponent == 0 {
+		return fmt.Sprintf("%d %s", minorUnits, currency)
+	}
+
+	sign := ""
+	if minorUnits < 0 {
+		sign = "-"
+		minorUnits = -minorUnits
+	}
+
+	scale := int64(math.Pow10(exponent))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, minorUnits/scale, exponent, minorUnits%scale, currency)
+}
+
+func String(m *api.Money) string {
+	if m == nil {
+		return "nothing"
+	}
+
+	return Format(m.MinorUnits, m.Currency)
+}
+
+// Parses an amount like 12.5 in the currency into minor units
+func Parse(amount string, currency string) (int64, error) {
+	exponent := exponent(currency)
+
+	whole, fraction := strings.TrimSpace(amount), ""
+	if i := strings.IndexByte(whole, '.'); i >= 0 {
+		whole, fraction = whole[:i], whole[i+1:]
+	}
+	if len(whole) == 0 || len(fraction) > exponent || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return 0, errMalformed
+	}
+
+	units, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, errMalformed
+	}
+
+	return units, nil
+}
@@ -0,0 +1,149 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ap/DMP3/api"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		want     int64
+		err      error
+	}{
+		{name: "whole", amount: "12", currency: "DKK", want: 1200},
+		{name: "decimals", amount: "12.50", currency: "DKK", want: 1250},
+		{name: "fewer decimals", amount: "12.5", currency: "DKK", want: 1250},
+		{name: "trailing point", amount: "12.", currency: "DKK", want: 1200},
+		{name: "surrounding space", amount: " 7.05 ", currency: "EUR", want: 705},
+		{name: "zero", amount: "0", currency: "DKK", want: 0},
+		{name: "more decimals than the currency is not rounded", amount: "12.505", currency: "DKK", err: errMalformed},
+		{name: "no decimals", amount: "1500", currency: "JPY", want: 1500},
+		{name: "decimals in a currency without", amount: "1500.5", currency: "JPY", err: errMalformed},
+		{name: "three decimals", amount: "1.234", currency: "BHD", want: 1234},
+		{name: "one of three decimals", amount: "1.2", currency: "BHD", want: 1200},
+		{name: "more than three decimals", amount: "1.2345", currency: "BHD", err: errMalformed},
+		{name: "negative", amount: "-12.50", currency: "DKK", err: errMalformed},
+		{name: "explicit sign", amount: "+12.50", currency: "DKK", err: errMalformed},
+		{name: "negative fraction", amount: "12.-5", currency: "DKK", err: errMalformed},
+		{name: "missing whole", amount: ".50", currency: "DKK", err: errMalformed},
+		{name: "empty", amount: "", currency: "DKK", err: errMalformed},
+		{name: "not a number", amount: "twelve", currency: "DKK", err: errMalformed},
+		{name: "two points", amount: "1.2.3", currency: "BHD", err: errMalformed},
+		{name: "too large", amount: "92233720368547758.08", currency: "DKK", err: errMalformed},
+		{name: "largest", amount: "92233720368547758.07", currency: "DKK", want: math.MaxInt64},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.amount, test.currency)
+			if err != test.err {
+				t.Fatalf("Parse(%q, %s) error = %v, want %v", test.amount, test.currency, err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("Parse(%q, %s) = %d, want %d", test.amount, test.currency, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		minorUnits int64
+		currency   string
+		want       string
+	}{
+		{name: "two decimals", minorUnits: 1250, currency: "DKK", want: "12.50 DKK"},
+		{name: "leading zero decimal", minorUnits: 705, currency: "EUR", want: "7.05 EUR"},
+		{name: "below one", minorUnits: 5, currency: "DKK", want: "0.05 DKK"},
+		{name: "zero", minorUnits: 0, currency: "DKK", want: "0.00 DKK"},
+		{name: "negative", minorUnits: -1250, currency: "DKK", want: "-12.50 DKK"},
+		{name: "negative below one", minorUnits: -5, currency: "DKK", want: "-0.05 DKK"},
+		{name: "no decimals", minorUnits: 1500, currency: "JPY", want: "1500 JPY"},
+		{name: "negative without decimals", minorUnits: -1500, currency: "JPY", want: "-1500 JPY"},
+		{name: "three decimals", minorUnits: 1234, currency: "BHD", want: "1.234 BHD"},
+		{name: "three decimals below one", minorUnits: 5, currency: "KWD", want: "0.005 KWD"},
+		{name: "unknown currency has two decimals", minorUnits: 1250, currency: "XYZ", want: "12.50 XYZ"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Format(test.minorUnits, test.currency); got != test.want {
+				t.Errorf("Format(%d, %s) = %q, want %q", test.minorUnits, test.currency, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatParse(t *testing.T) {
+	for _, currency := range []string{"DKK", "JPY", "BHD"} {
+		for _, minorUnits := range []int64{0, 1, 99, 100, 12345, math.MaxInt32 + 1} {
+			formatted := Format(minorUnits, currency)
+			amount := formatted[:len(formatted)-len(currency)-1]
+
+			got, err := Parse(amount, currency)
+			if err != nil {
+				t.Fatalf("Parse(%q, %s) error = %v", amount, currency, err)
+			}
+			if got != minorUnits {
+				t.Errorf("Parse(Format(%d, %s)) = %d", minorUnits, currency, got)
+			}
+		}
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	tests := []struct {
+		name       string
+		minorUnits int64
+		want       int32
+	}{
+		{name: "in range", minorUnits: 1250, want: 1250},
+		{name: "negative in range", minorUnits: -1250, want: -1250},
+		{name: "above", minorUnits: math.MaxInt32 + 1, want: math.MaxInt32},
+		{name: "below", minorUnits: math.MinInt32 - 1, want: math.MinInt32},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Legacy(test.minorUnits); got != test.want {
+				t.Errorf("Legacy(%d) = %d, want %d", test.minorUnits, got, test.want)
+			}
+		})
+	}
+}
+
+func TestPricesInCurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *api.CreateAuctionRequest
+		want bool
+	}{
+		{name: "no prices", req: &api.CreateAuctionRequest{}, want: true},
+		{name: "legacy prices", req: &api.CreateAuctionRequest{ReservePrice: 100, StartingPrice: 10}, want: true},
+		{name: "same currency", req: &api.CreateAuctionRequest{ReservePriceAmount: New(100, "DKK")}, want: true},
+		{name: "no currency", req: &api.CreateAuctionRequest{ReservePriceAmount: New(100, "")}, want: true},
+		{name: "other currency", req: &api.CreateAuctionRequest{StartingPriceAmount: New(100, "EUR")}},
+		{
+			name: "dutch schedule in other currency",
+			req:  &api.CreateAuctionRequest{Dutch: &api.DutchSchedule{StartPriceAmount: New(100, "DKK"), FloorPriceAmount: New(10, "EUR")}},
+		},
+		{
+			name: "increment tier in other currency",
+			req:  &api.CreateAuctionRequest{Increments: []*api.IncrementTier{{BelowAmount: New(1000, "EUR"), IncrementAmount: New(10, "DKK")}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := PricesInCurrency(test.req, "DKK"); got != test.want {
+				t.Errorf("PricesInCurrency() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,835 @@
+// Package raft implements the Raft consensus algorithm on top of the Raft
+// gRPC service in the api package.
+//
+// Commands are opaque to the package. They are replicated through the log
+// and handed to a StateMachine in log order once a majority of the cluster
+// stored them. The term, the vote and the log are kept in a write-ahead log so
+// a node can restart without breaking the safety guarantees. The log is never
+// compacted, a restarted node rebuilds its state machine by applying the
+// entries again from the start.
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"github.com/ap/DMP3/internal/logging"
+	"github.com/ap/DMP3/internal/wal"
+	"google.golang.org/grpc"
+)
+
+type Role int
+
+const (
+	Follower Role = iota
+	Candidate
+	Leader
+)
+
+const (
+	// Upper bound on the number of entries sent in a single AppendEntries
+	maxEntriesPerAppend = 64
+)
+
+var (
+	// Returned by Propose on a node that is not the leader, the caller should forward to Leader()
+	ErrNotLeader = errors.New("raft: not the leader")
+	// Returned by Propose when another leader overwrote the proposed entry
+	ErrLeadershipLost = errors.New("raft: leadership lost before the command was committed")
+
+	logger = logging.New()
+)
+
+func (r Role) String() string {
+	switch r {
+	case Follower:
+		return "follower"
+	case Candidate:
+		return "candidate"
+	case Leader:
+		return "leader"
+	default:
+		return "unknown"
+	}
+}
+
+// StateMachine receives the committed commands in log order
+type StateMachine interface {
+	// Called once for every committed entry. An empty command is a no-op
+	// appended by a new leader and should only advance the applied index
+	Apply(index uint64, command []byte) interface{}
+}
+
+type Config struct {
+	// Identifier of this node, must be a key in Peers
+	ID string
+	// Address of every node in the cluster by identifier, including this node
+	Peers map[string]string
+	// Directory to keep the term, vote and log in, nothing is persisted if empty
+	DataDir string
+	// Lower bound of the randomized election timeout, the upper bound is twice this
+	ElectionTimeout time.Duration
+	// Interval between heartbeats from the leader
+	HeartbeatInterval time.Duration
+	// Options used when dialing the other nodes
+	DialOptions []grpc.DialOption
+}
+
+// Entry is a single command in the log
+type Entry struct {
+	Index   uint64 `json:"index"`
+	Term    uint64 `json:"term"`
+	Command []byte `json:"command,omitempty"`
+}
+
+// Written to the store whenever the persistent state changes. Replaying an
+// entry truncates the log to just before its index, so records written after
+// a conflict overwrite the entries they replace
+type record struct {
+	Term     uint64   `json:"term"`
+	VotedFor string   `json:"votedFor,omitempty"`
+	Entries  []*Entry `json:"entries,omitempty"`
+}
+
+type proposal struct {
+	term uint64
+	done chan proposalResult
+}
+
+type proposalResult struct {
+	value interface{}
+	err   error
+}
+
+type Raft struct {
+	pb.UnimplementedRaftServer
+	config Config
+	sm     StateMachine
+	store  *wal.Log
+
+	lock        sync.Mutex
+	applyCond   *sync.Cond
+	role        Role
+	term        uint64
+	votedFor    string
+	leaderID    string
+	log         []*Entry // log[0] is a sentinel at index 0, term 0
+	commitIndex uint64
+	lastApplied uint64
+	nextIndex   map[string]uint64
+	matchIndex  map[string]uint64
+	lastHeard   time.Time
+	timeout     time.Duration
+	pending     map[uint64]*proposal
+
+	clients   map[string]pb.RaftClient
+	replicate map[string]chan struct{}
+}
+
+/*
+Restores the persistent state from the data directory and starts the
+election timer, the replication and the apply loops
+*/
+func New(config Config, sm StateMachine) (*Raft, error) {
+	if _, ok := config.Peers[config.ID]; !ok {
+		return nil, errors.New("raft: the node itself is missing from the peers")
+	}
+
+	r := &Raft{
+		config:     config,
+		sm:         sm,
+		role:       Follower,
+		log:        []*Entry{{Index: 0, Term: 0}},
+		nextIndex:  make(map[string]uint64),
+		matchIndex: make(map[string]uint64),
+		pending:    make(map[uint64]*proposal),
+		clients:    make(map[string]pb.RaftClient),
+		replicate:  make(map[string]chan struct{}),
+	}
+	r.applyCond = sync.NewCond(&r.lock)
+
+	if len(config.DataDir) > 0 {
+		if err := r.restore(); err != nil {
+			return nil, err
+		}
+	}
+
+	for id, addr := range config.Peers {
+		if id == config.ID {
+			continue
+		}
+
+		conn, err := grpc.Dial(addr, config.DialOptions...)
+		if err != nil {
+			return nil, err
+		}
+
+		r.clients[id] = pb.NewRaftClient(conn)
+		r.replicate[id] = make(chan struct{}, 1)
+	}
+
+	r.resetElectionTimer()
+	logger.IPrintf("Raft %s starting at term %d with %d log entries\n", config.ID, r.term, r.lastIndex())
+
+	go r.electionLoop()
+	go r.applyLoop()
+	for id := range r.clients {
+		go r.replicateLoop(id)
+	}
+
+	return r, nil
+}
+
+func (r *Raft) restore() error {
+	store, err := wal.Open(r.config.DataDir)
+	if err != nil {
+		return err
+	}
+
+	_, records, err := store.Replay()
+	if err != nil {
+		store.Close()
+		return err
+	}
+
+	for _, data := range records {
+		rec := &record{}
+		if err := json.Unmarshal(data, rec); err != nil {
+			store.Close()
+			return err
+		}
+
+		r.term = rec.Term
+		r.votedFor = rec.VotedFor
+		for _, entry := range rec.Entries {
+			r.log = append(r.log[:entry.Index], entry)
+		}
+	}
+
+	r.store = store
+	return nil
+}
+
+/*
+Writes the term, the vote and the given entries to the store. The caller must hold the lock
+*/
+func (r *Raft) persist(entries ...*Entry) error {
+	if r.store == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(&record{
+		Term:     r.term,
+		VotedFor: r.votedFor,
+		Entries:  entries,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.store.Append(data)
+}
+
+// Identifier of the current leader, empty if it is not known
+func (r *Raft) LeaderID() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.leaderID
+}
+
+// Address of the current leader, empty if it is not known
+func (r *Raft) Leader() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.leaderID) == 0 {
+		return ""
+	}
+
+	return r.config.Peers[r.leaderID]
+}
+
+func (r *Raft) Role() Role {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.role
+}
+
+func (r *Raft) Term() uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.term
+}
+
+/*
+Appends the command to the log and waits until it has been committed and
+applied, returning what the state machine returned for it
+*/
+func (r *Raft) Propose(ctx context.Context, command []byte) (interface{}, error) {
+	r.lock.Lock()
+	if r.role != Leader {
+		r.lock.Unlock()
+		return nil, ErrNotLeader
+	}
+
+	entry := &Entry{
+		Index:   r.lastIndex() + 1,
+		Term:    r.term,
+		Command: command,
+	}
+	if err := r.persist(entry); err != nil {
+		r.lock.Unlock()
+		return nil, err
+	}
+	r.log = append(r.log, entry)
+
+	p := &proposal{
+		term: entry.Term,
+		done: make(chan proposalResult, 1),
+	}
+	r.pending[entry.Index] = p
+
+	r.advanceCommitIndex()
+	r.triggerReplication()
+	r.lock.Unlock()
+
+	select {
+	case result := <-p.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		r.lock.Lock()
+		delete(r.pending, entry.Index)
+		r.lock.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (r *Raft) RequestVote(_ context.Context, req *pb.VoteRequest) (*pb.VoteReply, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req.Term > r.term {
+		r.stepDown(req.Term)
+	}
+
+	if req.Term < r.term {
+		return &pb.VoteReply{Term: r.term, VoteGranted: false}, nil
+	}
+
+	upToDate := req.LastLogTerm > r.lastTerm() ||
+		(req.LastLogTerm == r.lastTerm() && req.LastLogIndex >= r.lastIndex())
+	if !upToDate || (len(r.votedFor) > 0 && r.votedFor != req.CandidateId) {
+		return &pb.VoteReply{Term: r.term, VoteGranted: false}, nil
+	}
+
+	r.votedFor = req.CandidateId
+	if err := r.persist(); err != nil {
+		r.votedFor = ""
+		return nil, err
+	}
+
+	logger.IPrintf("Raft %s voted for %s in term %d\n", r.config.ID, req.CandidateId, r.term)
+	r.resetElectionTimer()
+
+	return &pb.VoteReply{Term: r.term, VoteGranted: true}, nil
+}
+
+func (r *Raft) AppendEntries(_ context.Context, req *pb.AppendEntriesRequest) (*pb.AppendEntriesReply, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req.Term < r.term {
+		return &pb.AppendEntriesReply{Term: r.term, Success: false}, nil
+	}
+
+	if req.Term > r.term || r.role != Follower {
+		r.stepDown(req.Term)
+	}
+	if r.leaderID != req.LeaderId {
+		logger.IPrintf("Raft %s follows %s in term %d\n", r.config.ID, req.LeaderId, r.term)
+		r.leaderID = req.LeaderId
+	}
+	r.resetElectionTimer()
+
+	if req.PrevLogIndex > r.lastIndex() {
+		return &pb.AppendEntriesReply{Term: r.term, Success: false, ConflictIndex: r.lastIndex() + 1}, nil
+	}
+
+	if prevTerm := r.log[req.PrevLogIndex].Term; prevTerm != req.PrevLogTerm {
+		// Skip back over the whole conflicting term instead of a single entry at a time
+		conflict := req.PrevLogIndex
+		for conflict > 1 && r.log[conflict-1].Term == prevTerm {
+			conflict--
+		}
+
+		return &pb.AppendEntriesReply{Term: r.term, Success: false, ConflictIndex: conflict}, nil
+	}
+
+	var added []*Entry
+	for i, e := range req.Entries {
+		if e.Index <= r.lastIndex() && r.log[e.Index].Term == e.Term {
+			continue
+		}
+
+		for _, rest := range req.Entries[i:] {
+			added = append(added, &Entry{Index: rest.Index, Term: rest.Term, Command: rest.Command})
+		}
+		break
+	}
+
+	if len(added) > 0 {
+		if err := r.persist(added...); err != nil {
+			return nil, err
+		}
+
+		r.log = r.log[:added[0].Index]
+		r.log = append(r.log, added...)
+	}
+
+	// A stale or reordered request may know of less than this node, so the commit index only ever moves up
+	lastNew := req.PrevLogIndex + uint64(len(req.Entries))
+	if commit := min(req.LeaderCommit, lastNew); commit > r.commitIndex {
+		r.commitIndex = commit
+		r.applyCond.Broadcast()
+	}
+
+	return &pb.AppendEntriesReply{Term: r.term, Success: true}, nil
+}
+
+/*
+Starts an election whenever the leader has been silent for longer than the election timeout
+*/
+func (r *Raft) electionLoop() {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.lock.Lock()
+		if r.role != Leader && time.Since(r.lastHeard) > r.timeout {
+			r.startElection()
+		}
+		r.lock.Unlock()
+	}
+}
+
+/*
+Becomes a candidate for the next term and asks every peer for its vote. The caller must hold the lock
+*/
+func (r *Raft) startElection() {
+	r.role = Candidate
+	r.term++
+	r.votedFor = r.config.ID
+	r.leaderID = ""
+	r.resetElectionTimer()
+
+	if err := r.persist(); err != nil {
+		logger.EPrintf("Raft %s failed to persist its vote: %v\n", r.config.ID, err)
+		return
+	}
+
+	logger.IPrintf("Raft %s starting election for term %d\n", r.config.ID, r.term)
+
+	req := &pb.VoteRequest{
+		Term:         r.term,
+		CandidateId:  r.config.ID,
+		LastLogIndex: r.lastIndex(),
+		LastLogTerm:  r.lastTerm(),
+	}
+
+	votes := 1
+	if votes >= r.majority() {
+		r.becomeLeader()
+		return
+	}
+
+	for id, client := range r.clients {
+		go func(id string, client pb.RaftClient) {
+			ctx, cancel := context.WithTimeout(context.Background(), r.config.ElectionTimeout)
+			defer cancel()
+
+			reply, err := client.RequestVote(ctx, req)
+			if err != nil {
+				return
+			}
+
+			r.lock.Lock()
+			defer r.lock.Unlock()
+
+			if reply.Term > r.term {
+				r.stepDown(reply.Term)
+				return
+			}
+
+			if r.role != Candidate || r.term != req.Term || !reply.VoteGranted {
+				return
+			}
+
+			votes++
+			if votes >= r.majority() {
+				r.becomeLeader()
+			}
+		}(id, client)
+	}
+}
+
+/*
+Takes over as leader and appends a no-op so entries from earlier terms get
+committed. The caller must hold the lock
+*/
+func (r *Raft) becomeLeader() {
+	logger.IPrintf("Raft %s is leader for term %d\n", r.config.ID, r.term)
+
+	r.role = Leader
+	r.leaderID = r.config.ID
+	for id := range r.clients {
+		r.nextIndex[id] = r.lastIndex() + 1
+		r.matchIndex[id] = 0
+	}
+
+	noop := &Entry{
+		Index: r.lastIndex() + 1,
+		Term:  r.term,
+	}
+	if err := r.persist(noop); err != nil {
+		logger.EPrintf("Raft %s failed to persist its no-op: %v\n", r.config.ID, err)
+		r.stepDown(r.term)
+		return
+	}
+	r.log = append(r.log, noop)
+
+	r.advanceCommitIndex()
+	r.triggerReplication()
+}
+
+/*
+Reverts to follower, moving to the given term if it is newer. The caller must hold the lock
+*/
+func (r *Raft) stepDown(term uint64) {
+	if term > r.term {
+		r.term = term
+		r.votedFor = ""
+		r.leaderID = ""
+		if err := r.persist(); err != nil {
+			logger.EPrintf("Raft %s failed to persist term %d: %v\n", r.config.ID, term, err)
+		}
+	}
+
+	if r.role != Follower {
+		logger.IPrintf("Raft %s stepping down to follower in term %d\n", r.config.ID, r.term)
+	}
+
+	r.role = Follower
+	r.resetElectionTimer()
+}
+
+/*
+Keeps a single peer up to date while this node is the leader. It sends
+entries whenever it is triggered and an empty heartbeat otherwise
+*/
+func (r *Raft) replicateLoop(id string) {
+	ticker := time.NewTicker(r.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-r.replicate[id]:
+		}
+
+		r.sendAppendEntries(id)
+	}
+}
+
+func (r *Raft) sendAppendEntries(id string) {
+	r.lock.Lock()
+	if r.role != Leader {
+		r.lock.Unlock()
+		return
+	}
+	req := r.appendRequest(id)
+	r.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.ElectionTimeout)
+	defer cancel()
+
+	reply, err := r.clients[id].AppendEntries(ctx, req)
+	if err != nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.handleAppendReply(id, req, reply)
+}
+
+/*
+Builds the next AppendEntries for a peer, with the entries it is missing. The caller must hold the lock
+*/
+func (r *Raft) appendRequest(id string) *pb.AppendEntriesRequest {
+	prevIndex := r.nextIndex[id] - 1
+	end := min(r.lastIndex(), prevIndex+maxEntriesPerAppend)
+
+	entries := make([]*pb.LogEntry, 0, end-prevIndex)
+	for _, e := range r.log[prevIndex+1 : end+1] {
+		entries = append(entries, &pb.LogEntry{Index: e.Index, Term: e.Term, Command: e.Command})
+	}
+
+	return &pb.AppendEntriesRequest{
+		Term:         r.term,
+		LeaderId:     r.config.ID,
+		PrevLogIndex: prevIndex,
+		PrevLogTerm:  r.log[prevIndex].Term,
+		Entries:      entries,
+		LeaderCommit: r.commitIndex,
+	}
+}
+
+/*
+Moves the indexes of a peer along with its answer to an AppendEntries. The caller must hold the lock
+*/
+func (r *Raft) handleAppendReply(id string, req *pb.AppendEntriesRequest, reply *pb.AppendEntriesReply) {
+	if reply.Term > r.term {
+		r.stepDown(reply.Term)
+		return
+	}
+
+	if r.role != Leader || r.term != req.Term {
+		return
+	}
+
+	if reply.Success {
+		match := req.PrevLogIndex + uint64(len(req.Entries))
+		if match > r.matchIndex[id] {
+			r.matchIndex[id] = match
+		}
+		r.nextIndex[id] = r.matchIndex[id] + 1
+
+		r.advanceCommitIndex()
+	} else {
+		r.nextIndex[id] = max(1, min(reply.ConflictIndex, r.lastIndex()+1))
+	}
+
+	if r.nextIndex[id] <= r.lastIndex() {
+		r.trigger(id)
+	}
+}
+
+/*
+Waits until reads may be served from the state machine without returning
+stale data, following the read index protocol. The leader takes its commit
+index once it committed an entry of its own term, confirms a majority still
+follows it with a round of heartbeats, and waits until the state machine
+applied up to the index. A leader that was deposed without noticing cannot
+get the majority, so it returns ErrNotLeader instead of serving a stale read
+*/
+func (r *Raft) ReadIndex(ctx context.Context) (uint64, error) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	// Until the no-op of its term is committed the leader may not know everything that was committed before
+	r.lock.Lock()
+	for r.role == Leader && r.log[r.commitIndex].Term != r.term {
+		r.lock.Unlock()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		r.lock.Lock()
+	}
+	if r.role != Leader {
+		r.lock.Unlock()
+		return 0, ErrNotLeader
+	}
+	term := r.term
+	index := r.commitIndex
+	r.lock.Unlock()
+
+	if err := r.confirmLeadership(ctx, term); err != nil {
+		return 0, err
+	}
+
+	for {
+		r.lock.Lock()
+		applied := r.lastApplied
+		r.lock.Unlock()
+		if applied >= index {
+			return index, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+/*
+Sends a round of AppendEntries to every peer and returns once a majority,
+counting this node, acknowledged it as the leader of the term
+*/
+func (r *Raft) confirmLeadership(ctx context.Context, term uint64) error {
+	acks := make(chan bool, len(r.clients))
+	for id, client := range r.clients {
+		r.lock.Lock()
+		if r.role != Leader || r.term != term {
+			r.lock.Unlock()
+			return ErrNotLeader
+		}
+		req := r.appendRequest(id)
+		r.lock.Unlock()
+
+		go func(id string, client pb.RaftClient) {
+			ctx, cancel := context.WithTimeout(ctx, r.config.ElectionTimeout)
+			defer cancel()
+
+			reply, err := client.AppendEntries(ctx, req)
+			if err != nil {
+				acks <- false
+				return
+			}
+
+			r.lock.Lock()
+			defer r.lock.Unlock()
+
+			// A follower that did not have the entries before the request still follows this term
+			if r.role == Leader && r.term == req.Term {
+				r.handleAppendReply(id, req, reply)
+			} else if reply.Term > r.term {
+				r.stepDown(reply.Term)
+			}
+			acks <- reply.Term == req.Term
+		}(id, client)
+	}
+
+	confirmed := 1
+	for answered := 0; confirmed < r.majority() && answered < len(r.clients); answered++ {
+		select {
+		case ack := <-acks:
+			if ack {
+				confirmed++
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if confirmed < r.majority() {
+		logger.EPrintf("Raft %s could not confirm it still leads term %d\n", r.config.ID, term)
+		return ErrNotLeader
+	}
+
+	return nil
+}
+
+/*
+Commits the highest entry from the current term stored on a majority. The caller must hold the lock
+*/
+func (r *Raft) advanceCommitIndex() {
+	for n := r.lastIndex(); n > r.commitIndex; n-- {
+		if r.log[n].Term != r.term {
+			break
+		}
+
+		stored := 1
+		for _, match := range r.matchIndex {
+			if match >= n {
+				stored++
+			}
+		}
+
+		if stored >= r.majority() {
+			r.commitIndex = n
+			r.applyCond.Broadcast()
+			r.triggerReplication()
+			return
+		}
+	}
+}
+
+/*
+Hands committed entries to the state machine in order and completes the proposals waiting for them
+*/
+func (r *Raft) applyLoop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for {
+		for r.lastApplied >= r.commitIndex {
+			r.applyCond.Wait()
+		}
+
+		entry := r.log[r.lastApplied+1]
+		r.lock.Unlock()
+		value := r.sm.Apply(entry.Index, entry.Command)
+		r.lock.Lock()
+
+		r.lastApplied = entry.Index
+		if p, ok := r.pending[entry.Index]; ok {
+			delete(r.pending, entry.Index)
+			if p.term == entry.Term {
+				p.done <- proposalResult{value: value}
+			} else {
+				p.done <- proposalResult{err: ErrLeadershipLost}
+			}
+		}
+	}
+}
+
+// The caller must hold the lock
+func (r *Raft) triggerReplication() {
+	for id := range r.replicate {
+		r.trigger(id)
+	}
+}
+
+func (r *Raft) trigger(id string) {
+	select {
+	case r.replicate[id] <- struct{}{}:
+	default:
+	}
+}
+
+// The caller must hold the lock
+func (r *Raft) resetElectionTimer() {
+	r.lastHeard = time.Now()
+	r.timeout = r.config.ElectionTimeout + time.Duration(rand.Int63n(int64(r.config.ElectionTimeout)))
+}
+
+func (r *Raft) majority() int {
+	return len(r.config.Peers)/2 + 1
+}
+
+func (r *Raft) lastIndex() uint64 {
+	return r.log[len(r.log)-1].Index
+}
+
+func (r *Raft) lastTerm() uint64 {
+	return r.log[len(r.log)-1].Term
+}
+
+func min(a uint64, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max(a uint64, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
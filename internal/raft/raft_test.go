@@ -0,0 +1,355 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/ap/DMP3/api"
+	"google.golang.org/grpc"
+)
+
+// Answers AppendEntries with a fixed term, or fails if err is set
+type fakeClient struct {
+	term uint64
+	err  error
+}
+
+func (c *fakeClient) RequestVote(context.Context, *pb.VoteRequest, ...grpc.CallOption) (*pb.VoteReply, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeClient) AppendEntries(_ context.Context, req *pb.AppendEntriesRequest, _ ...grpc.CallOption) (*pb.AppendEntriesReply, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	term := c.term
+	if term == 0 {
+		term = req.Term
+	}
+
+	return &pb.AppendEntriesReply{Term: term, Success: term == req.Term}, nil
+}
+
+/*
+A node of a cluster of the given size with a log of entries in the given
+terms, without a store or running loops
+*/
+func newTestRaft(size int, terms ...uint64) *Raft {
+	peers := make(map[string]string)
+	for i := 1; i <= size; i++ {
+		peers[fmt.Sprint(i)] = fmt.Sprintf("localhost:%d", 6000+i)
+	}
+
+	r := &Raft{
+		config: Config{
+			ID:              "1",
+			Peers:           peers,
+			ElectionTimeout: time.Second,
+		},
+		role:       Follower,
+		log:        []*Entry{{Index: 0, Term: 0}},
+		nextIndex:  make(map[string]uint64),
+		matchIndex: make(map[string]uint64),
+		pending:    make(map[uint64]*proposal),
+		clients:    make(map[string]pb.RaftClient),
+		replicate:  make(map[string]chan struct{}),
+	}
+	r.applyCond = sync.NewCond(&r.lock)
+
+	for i, term := range terms {
+		r.log = append(r.log, &Entry{Index: uint64(i + 1), Term: term})
+	}
+	if len(terms) > 0 {
+		r.term = terms[len(terms)-1]
+	}
+
+	return r
+}
+
+func logTerms(r *Raft) []uint64 {
+	terms := []uint64{}
+	for _, entry := range r.log[1:] {
+		terms = append(terms, entry.Term)
+	}
+
+	return terms
+}
+
+func logEntries(prevIndex uint64, terms ...uint64) []*pb.LogEntry {
+	var entries []*pb.LogEntry
+	for i, term := range terms {
+		entries = append(entries, &pb.LogEntry{Index: prevIndex + uint64(i) + 1, Term: term})
+	}
+
+	return entries
+}
+
+func TestAppendEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		// Terms of the entries in the log of the follower, and its commit index
+		log    []uint64
+		commit uint64
+		req    *pb.AppendEntriesRequest
+		// What the follower answers and ends up with
+		success    bool
+		conflict   uint64
+		wantLog    []uint64
+		wantCommit uint64
+	}{
+		{
+			name:       "append to an empty log",
+			req:        &pb.AppendEntriesRequest{Term: 1, LeaderId: "2", Entries: logEntries(0, 1, 1), LeaderCommit: 1},
+			success:    true,
+			wantLog:    []uint64{1, 1},
+			wantCommit: 1,
+		},
+		{
+			name:       "stale term",
+			log:        []uint64{1, 2},
+			req:        &pb.AppendEntriesRequest{Term: 1, LeaderId: "2", PrevLogIndex: 2, PrevLogTerm: 2},
+			wantLog:    []uint64{1, 2},
+			wantCommit: 0,
+		},
+		{
+			name:     "previous entry missing",
+			log:      []uint64{1},
+			req:      &pb.AppendEntriesRequest{Term: 2, LeaderId: "2", PrevLogIndex: 3, PrevLogTerm: 2, Entries: logEntries(3, 2)},
+			conflict: 2,
+			wantLog:  []uint64{1},
+		},
+		{
+			name:     "previous entry from another term skips back over the term",
+			log:      []uint64{1, 2, 2, 2},
+			req:      &pb.AppendEntriesRequest{Term: 3, LeaderId: "2", PrevLogIndex: 4, PrevLogTerm: 3},
+			conflict: 2,
+			wantLog:  []uint64{1, 2, 2, 2},
+		},
+		{
+			name:       "conflicting entries are overwritten",
+			log:        []uint64{1, 2, 2},
+			req:        &pb.AppendEntriesRequest{Term: 3, LeaderId: "2", PrevLogIndex: 1, PrevLogTerm: 1, Entries: logEntries(1, 3), LeaderCommit: 2},
+			success:    true,
+			wantLog:    []uint64{1, 3},
+			wantCommit: 2,
+		},
+		{
+			name:       "entries already in the log keep the ones after them",
+			log:        []uint64{1, 1, 1},
+			req:        &pb.AppendEntriesRequest{Term: 1, LeaderId: "2", PrevLogIndex: 0, PrevLogTerm: 0, Entries: logEntries(0, 1), LeaderCommit: 3},
+			success:    true,
+			wantLog:    []uint64{1, 1, 1},
+			wantCommit: 1,
+		},
+		{
+			name:       "commit index stops at the last new entry",
+			log:        []uint64{1},
+			req:        &pb.AppendEntriesRequest{Term: 1, LeaderId: "2", PrevLogIndex: 1, PrevLogTerm: 1, Entries: logEntries(1, 1), LeaderCommit: 5},
+			success:    true,
+			wantLog:    []uint64{1, 1},
+			wantCommit: 2,
+		},
+		{
+			name:       "stale heartbeat does not move the commit index back",
+			log:        []uint64{1, 1, 1},
+			commit:     3,
+			req:        &pb.AppendEntriesRequest{Term: 1, LeaderId: "2", PrevLogIndex: 1, PrevLogTerm: 1, LeaderCommit: 4},
+			success:    true,
+			wantLog:    []uint64{1, 1, 1},
+			wantCommit: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newTestRaft(3, test.log...)
+			r.commitIndex = test.commit
+
+			reply, err := r.AppendEntries(context.Background(), test.req)
+			if err != nil {
+				t.Fatalf("AppendEntries() error = %v", err)
+			}
+
+			if reply.Success != test.success {
+				t.Errorf("AppendEntries() success = %t, want %t", reply.Success, test.success)
+			}
+			if reply.ConflictIndex != test.conflict {
+				t.Errorf("AppendEntries() conflict index = %d, want %d", reply.ConflictIndex, test.conflict)
+			}
+			if got := logTerms(r); !reflect.DeepEqual(got, append([]uint64{}, test.wantLog...)) {
+				t.Errorf("log terms = %v, want %v", got, test.wantLog)
+			}
+			if r.commitIndex != test.wantCommit {
+				t.Errorf("commit index = %d, want %d", r.commitIndex, test.wantCommit)
+			}
+		})
+	}
+}
+
+func TestAdvanceCommitIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		// Terms of the entries in the log of the leader, the current term is the last one
+		log   []uint64
+		term  uint64
+		match map[string]uint64
+		want  uint64
+	}{
+		{
+			name:  "stored on a majority",
+			log:   []uint64{1, 1, 1},
+			match: map[string]uint64{"2": 2, "3": 0},
+			want:  2,
+		},
+		{
+			name:  "stored on a minority",
+			log:   []uint64{1, 1, 1},
+			match: map[string]uint64{"2": 0, "3": 0},
+			want:  0,
+		},
+		{
+			name:  "highest index stored on a majority",
+			log:   []uint64{1, 1, 1},
+			match: map[string]uint64{"2": 3, "3": 1},
+			want:  3,
+		},
+		{
+			name:  "entries of earlier terms are not committed by counting",
+			log:   []uint64{1, 1, 2},
+			term:  3,
+			match: map[string]uint64{"2": 3, "3": 3},
+			want:  0,
+		},
+		{
+			name:  "entries of earlier terms are committed with one of the current term",
+			log:   []uint64{1, 2, 3},
+			match: map[string]uint64{"2": 3, "3": 0},
+			want:  3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newTestRaft(3, test.log...)
+			if test.term > 0 {
+				r.term = test.term
+			}
+			r.role = Leader
+			r.matchIndex = test.match
+
+			r.advanceCommitIndex()
+			if r.commitIndex != test.want {
+				t.Errorf("commit index = %d, want %d", r.commitIndex, test.want)
+			}
+		})
+	}
+}
+
+func TestRequestVote(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      []uint64
+		votedFor string
+		req      *pb.VoteRequest
+		granted  bool
+	}{
+		{
+			name:    "candidate log as long",
+			log:     []uint64{1, 1},
+			req:     &pb.VoteRequest{Term: 2, CandidateId: "2", LastLogIndex: 2, LastLogTerm: 1},
+			granted: true,
+		},
+		{
+			name: "candidate log shorter",
+			log:  []uint64{1, 1},
+			req:  &pb.VoteRequest{Term: 2, CandidateId: "2", LastLogIndex: 1, LastLogTerm: 1},
+		},
+		{
+			name:    "candidate log shorter but of a later term",
+			log:     []uint64{1, 1},
+			req:     &pb.VoteRequest{Term: 3, CandidateId: "2", LastLogIndex: 1, LastLogTerm: 2},
+			granted: true,
+		},
+		{
+			name: "stale term",
+			log:  []uint64{2},
+			req:  &pb.VoteRequest{Term: 1, CandidateId: "2", LastLogIndex: 5, LastLogTerm: 1},
+		},
+		{
+			name:     "already voted for another candidate",
+			log:      []uint64{1},
+			votedFor: "3",
+			req:      &pb.VoteRequest{Term: 1, CandidateId: "2", LastLogIndex: 1, LastLogTerm: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newTestRaft(3, test.log...)
+			r.votedFor = test.votedFor
+
+			reply, err := r.RequestVote(context.Background(), test.req)
+			if err != nil {
+				t.Fatalf("RequestVote() error = %v", err)
+			}
+			if reply.VoteGranted != test.granted {
+				t.Errorf("RequestVote() granted = %t, want %t", reply.VoteGranted, test.granted)
+			}
+		})
+	}
+}
+
+func TestReadIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		peers   map[string]*fakeClient
+		err     error
+		stepped bool
+	}{
+		{
+			name:  "majority follows",
+			peers: map[string]*fakeClient{"2": {}, "3": {err: errors.New("unreachable")}},
+		},
+		{
+			name:  "partitioned",
+			peers: map[string]*fakeClient{"2": {err: errors.New("unreachable")}, "3": {err: errors.New("unreachable")}},
+			err:   ErrNotLeader,
+		},
+		{
+			name:    "deposed",
+			peers:   map[string]*fakeClient{"2": {term: 3}, "3": {term: 3}},
+			err:     ErrNotLeader,
+			stepped: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newTestRaft(3, 1, 2)
+			r.role = Leader
+			r.commitIndex = 2
+			r.lastApplied = 2
+			for id, client := range test.peers {
+				r.clients[id] = client
+				r.nextIndex[id] = 3
+			}
+
+			index, err := r.ReadIndex(context.Background())
+			if err != test.err {
+				t.Fatalf("ReadIndex() error = %v, want %v", err, test.err)
+			}
+			if err == nil && index != 2 {
+				t.Errorf("ReadIndex() = %d, want 2", index)
+			}
+			if stepped := r.Role() != Leader; stepped != test.stepped {
+				t.Errorf("stepped down = %t, want %t", stepped, test.stepped)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// Package wal implements an append-only, checksummed log with snapshots.
+//
+// Every record is framed as a 4 byte length, a 4 byte CRC32 (Castagnoli) of
+// the payload and the payload itself. A record is fsync'd before Append
+// returns. A snapshot replaces the log, it is written to a temporary file,
+// fsync'd and renamed into place before the log is truncated.
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	logFileName      = "bids.log"
+	snapshotFileName = "snapshot"
+	headerSize       = 8
+	// Upper bound on a single record, anything larger is treated as corruption
+	maxRecordSize = 64 << 20
+)
+
+var (
+	// Returned when a record in the middle of the log or a snapshot fails its checksum
+	ErrCorrupt = errors.New("wal: corrupt record")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+type Log struct {
+	dir  string
+	file *os.File
+	lock sync.Mutex
+	// Set once a failed append could not be rolled back, every append after it fails
+	err error
+}
+
+// Opens the log in dir, creating the directory if it does not exist
+func Open(dir string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Log{
+		dir:  dir,
+		file: file,
+	}, nil
+}
+
+// Reads the latest snapshot and every record appended after it. A torn
+// record at the end of the log, left behind by a crash in the middle of a
+// write, is truncated away. Any other checksum failure returns ErrCorrupt.
+func (l *Log) Replay() (snapshot []byte, records [][]byte, err error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	snapshot, err = readSnapshot(filepath.Join(l.dir, snapshotFileName))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	reader := bufio.NewReader(l.file)
+	var offset int64
+	for {
+		record, size, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		} else if err == io.ErrUnexpectedEOF || (err == ErrCorrupt && isEOF(reader)) {
+			// Torn write at the tail, drop it so new records are appended after the last good one
+			if err := l.file.Truncate(offset); err != nil {
+				return nil, nil, err
+			}
+			if err := l.file.Sync(); err != nil {
+				return nil, nil, err
+			}
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("%w at offset %d", err, offset)
+		}
+
+		records = append(records, record)
+		offset += size
+	}
+
+	if _, err := l.file.Seek(offset, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	return snapshot, records, nil
+}
+
+// Appends a record to the log and waits for it to reach the disk. A record
+// that fails to be written or synced is truncated away again, so the next
+// one is appended after the last good record rather than after a partial one.
+func (l *Log) Append(record []byte) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.err != nil {
+		return l.err
+	}
+
+	offset, err := l.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = l.file.Write(frame(record))
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		if rollbackErr := l.rollback(offset); rollbackErr != nil {
+			l.err = fmt.Errorf("wal: log is broken, failed to roll back an append: %w", rollbackErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Drops everything written after offset and moves the writer back to it
+func (l *Log) rollback(offset int64) error {
+	if err := l.file.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := l.file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	return l.file.Sync()
+}
+
+// Atomically replaces the snapshot and empties the log. The caller must make
+// sure the snapshot covers every record appended so far.
+func (l *Log) Snapshot(snapshot []byte) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	path := filepath.Join(l.dir, snapshotFileName)
+	tmp := path + ".tmp"
+
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(frame(snapshot)); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	if err := syncDir(l.dir); err != nil {
+		return err
+	}
+
+	// A crash before this point leaves the old records behind the new snapshot,
+	// callers skip them by the sequence numbers they carry
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return l.file.Sync()
+}
+
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.file.Close()
+}
+
+func frame(record []byte) []byte {
+	buf := make([]byte, headerSize+len(record))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(record)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.Checksum(record, crcTable))
+	copy(buf[headerSize:], record)
+
+	return buf
+}
+
+// Reads a single framed record, returning the record and the number of bytes consumed
+func readRecord(reader io.Reader) ([]byte, int64, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, 0, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	checksum := binary.BigEndian.Uint32(header[4:8])
+	if length > maxRecordSize {
+		return nil, 0, ErrCorrupt
+	}
+
+	record := make([]byte, length)
+	if _, err := io.ReadFull(reader, record); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+
+	if crc32.Checksum(record, crcTable) != checksum {
+		return nil, 0, ErrCorrupt
+	}
+
+	return record, int64(headerSize + length), nil
+}
+
+func readSnapshot(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Snapshots are renamed into place only after being fsync'd, so a bad one is never a torn write
+	snapshot, _, err := readRecord(file)
+	if err == ErrCorrupt {
+		return nil, fmt.Errorf("%w in snapshot", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: truncated snapshot", ErrCorrupt)
+	}
+
+	return snapshot, nil
+}
+
+func isEOF(reader *bufio.Reader) bool {
+	_, err := reader.Peek(1)
+	return err == io.EOF
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
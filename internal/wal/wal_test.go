@@ -0,0 +1,265 @@
+package wal
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func records(payloads ...string) [][]byte {
+	var records [][]byte
+	for _, payload := range payloads {
+		records = append(records, []byte(payload))
+	}
+
+	return records
+}
+
+func frames(payloads ...string) []byte {
+	var buf []byte
+	for _, payload := range payloads {
+		buf = append(buf, frame([]byte(payload))...)
+	}
+
+	return buf
+}
+
+// Flips a byte in the payload of the record with the given index
+func corrupt(log []byte, index int) []byte {
+	log = append([]byte{}, log...)
+	offset := 0
+	for i := 0; i < index; i++ {
+		offset += headerSize + int(binary.BigEndian.Uint32(log[offset:]))
+	}
+	log[offset+headerSize] ^= 0xff
+
+	return log
+}
+
+func TestReplay(t *testing.T) {
+	whole := frames("one", "two", "three")
+
+	tests := []struct {
+		name     string
+		snapshot []byte
+		log      []byte
+		want     [][]byte
+		// Size the log is truncated to when replayed
+		size    int
+		corrupt bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "whole",
+			log:  whole,
+			want: records("one", "two", "three"),
+			size: len(whole),
+		},
+		{
+			name: "torn header at the tail",
+			log:  whole[:len(frames("one", "two"))+headerSize/2],
+			want: records("one", "two"),
+			size: len(frames("one", "two")),
+		},
+		{
+			name: "torn payload at the tail",
+			log:  whole[:len(whole)-1],
+			want: records("one", "two"),
+			size: len(frames("one", "two")),
+		},
+		{
+			name: "corrupt record at the tail",
+			log:  corrupt(whole, 2),
+			want: records("one", "two"),
+			size: len(frames("one", "two")),
+		},
+		{
+			name:    "corrupt record in the middle",
+			log:     corrupt(whole, 1),
+			corrupt: true,
+		},
+		{
+			name:     "snapshot",
+			snapshot: frame([]byte("state")),
+			log:      frames("four"),
+			want:     records("four"),
+			size:     len(frames("four")),
+		},
+		{
+			name:     "corrupt snapshot",
+			snapshot: corrupt(frame([]byte("state")), 0),
+			corrupt:  true,
+		},
+		{
+			name:     "truncated snapshot",
+			snapshot: frame([]byte("state"))[:headerSize+2],
+			corrupt:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, logFileName), test.log, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if test.snapshot != nil {
+				if err := os.WriteFile(filepath.Join(dir, snapshotFileName), test.snapshot, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			l, err := Open(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer l.Close()
+
+			snapshot, got, err := l.Replay()
+			if test.corrupt {
+				if !errors.Is(err, ErrCorrupt) {
+					t.Fatalf("Replay() error = %v, want ErrCorrupt", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+
+			if test.snapshot != nil && string(snapshot) != "state" {
+				t.Errorf("Replay() snapshot = %q, want %q", snapshot, "state")
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Replay() records = %q, want %q", got, test.want)
+			}
+
+			info, err := os.Stat(filepath.Join(dir, logFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != int64(test.size) {
+				t.Errorf("log is %d bytes after replay, want %d", info.Size(), test.size)
+			}
+		})
+	}
+}
+
+// Records appended after a torn tail was dropped follow the last good record
+func TestAppendAfterTornTail(t *testing.T) {
+	dir := t.TempDir()
+	whole := frames("one", "two")
+	if err := os.WriteFile(filepath.Join(dir, logFileName), whole[:len(whole)-1], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l.Replay(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Append([]byte("three")); err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+
+	l, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	_, got, err := l.Replay()
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if want := records("one", "three"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Replay() records = %q, want %q", got, want)
+	}
+}
+
+// A partial record left by a failed append is dropped before the next append
+func TestRollback(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Append([]byte("one")); err != nil {
+		t.Fatal(err)
+	}
+
+	offset, err := l.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.file.Write(frame([]byte("two"))[:headerSize+1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.rollback(offset); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Append([]byte("three")); err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+
+	l, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	_, got, err := l.Replay()
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if want := records("one", "three"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Replay() records = %q, want %q", got, want)
+	}
+}
+
+// A snapshot empties the log, and records appended after it are replayed on top of it
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records("one", "two") {
+		if err := l.Append(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Snapshot([]byte("state")); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Append([]byte("three")); err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+
+	l, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	snapshot, got, err := l.Replay()
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if string(snapshot) != "state" {
+		t.Errorf("Replay() snapshot = %q, want %q", snapshot, "state")
+	}
+	if want := records("three"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Replay() records = %q, want %q", got, want)
+	}
+}